@@ -0,0 +1,175 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BagTester compares a slice or array to a list of expected items without
+// regard to order. Each expected item can be either a literal value or a
+// Contester.
+//
+// When the test fails, every expected item which could not be matched is
+// reported with a path element like `Bag[1]`, and every element of the actual
+// value which was left over is reported with its index.
+type BagTester struct {
+	kind  string
+	items []any
+}
+
+// Bag returns a `*BagTester` which treats the expected items as a multiset.
+// Every expected item must match a different element of the actual value,
+// and every element of the actual value must be matched by an expected item.
+// This means that `c.Bag(1, 1, 2)` matches `[]int{2, 1, 1}` but not
+// `[]int{1, 2}`.
+//
+// When the expected items include Contesters, an element may match more than
+// one item. The tester finds the assignment of elements to items which
+// matches the most items, so the order of the expected items does not affect
+// the result.
+func (c *C) Bag(items ...any) *BagTester {
+	return &BagTester{kind: "Bag", items: items}
+}
+
+// Set returns a `*BagTester` which treats both the expected items and the
+// elements of the actual value as sets, so duplicates collapse. Every expected
+// item must match at least one element, and every element must match at
+// least one expected item. This means that `c.Set(1, 2)` matches
+// `[]int{2, 1, 1}`.
+func (c *C) Set(items ...any) *BagTester {
+	return &BagTester{kind: "Set", items: items}
+}
+
+func (bt *BagTester) Test(c *C, actual any) []*Result {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []*Result{c.FailType(actual, bt.items, bt.kind).WithDescription(fmt.Sprintf(
+			"Expected a slice or array but got %s",
+			articleize(describeTypeOfActualValue(actual)),
+		))}
+	}
+
+	matches := bt.matches(c, v)
+
+	var unmatchedItems, leftoverElements []int
+	if bt.kind == "Bag" {
+		unmatchedItems, leftoverElements = maximumMatching(matches, v.Len())
+	} else {
+		unmatchedItems, leftoverElements = anyMatching(matches, v.Len())
+	}
+
+	if len(unmatchedItems) == 0 && len(leftoverElements) == 0 {
+		return []*Result{c.Pass(actual, bt.items, bt.kind)}
+	}
+
+	var res []*Result
+	for _, i := range unmatchedItems {
+		c.PushPath(Path{data: fmt.Sprintf("%s[%d]", bt.kind, i)})
+		desc := "No element of the %s matched this expected item"
+		if bt.kind == "Bag" {
+			desc = "No element of the %s was left to match this expected item"
+		}
+		r := c.FailStructure(fmt.Sprintf(desc, describeTypeOfActualValue(actual)))
+		if _, ok := bt.items[i].(Contester); !ok {
+			r.expect = newValue(bt.items[i])
+		}
+		res = append(res, r)
+		c.PopPath()
+	}
+
+	for _, j := range leftoverElements {
+		c.PushPath(Path{data: fmt.Sprintf("[%d]", j)})
+		r := c.FailStructure(fmt.Sprintf(
+			"This element did not match any of the items in the %s",
+			bt.kind,
+		))
+		r.actual = newValue(v.Index(j).Interface())
+		res = append(res, r)
+		c.PopPath()
+	}
+
+	return res
+}
+
+// matches returns a matrix where `matches[i][j]` is true if expected item `i`
+// matches element `j` of the actual value.
+func (bt *BagTester) matches(c *C, v reflect.Value) [][]bool {
+	matches := make([][]bool, len(bt.items))
+	for i, item := range bt.items {
+		matches[i] = make([]bool, v.Len())
+		for j := 0; j < v.Len(); j++ {
+			matches[i][j] = allPassed(c.is(v.Index(j).Interface(), item))
+		}
+	}
+	return matches
+}
+
+// maximumMatching finds a maximum bipartite matching between expected items
+// and elements, using augmenting paths. It returns the indexes of the items
+// and the elements which are not part of the matching.
+func maximumMatching(matches [][]bool, elements int) ([]int, []int) {
+	itemFor := make([]int, elements)
+	for j := range itemFor {
+		itemFor[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j, ok := range matches[i] {
+			if !ok || seen[j] {
+				continue
+			}
+			seen[j] = true
+			if itemFor[j] == -1 || augment(itemFor[j], seen) {
+				itemFor[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	var unmatchedItems []int
+	for i := range matches {
+		if !augment(i, make([]bool, elements)) {
+			unmatchedItems = append(unmatchedItems, i)
+		}
+	}
+
+	var leftoverElements []int
+	for j, i := range itemFor {
+		if i == -1 {
+			leftoverElements = append(leftoverElements, j)
+		}
+	}
+
+	return unmatchedItems, leftoverElements
+}
+
+// anyMatching returns the indexes of the expected items which match no
+// element and the elements which match no expected item.
+func anyMatching(matches [][]bool, elements int) ([]int, []int) {
+	elementMatched := make([]bool, elements)
+
+	var unmatchedItems []int
+	for i, row := range matches {
+		found := false
+		for j, ok := range row {
+			if ok {
+				found = true
+				elementMatched[j] = true
+			}
+		}
+		if !found {
+			unmatchedItems = append(unmatchedItems, i)
+		}
+	}
+
+	var leftoverElements []int
+	for j, ok := range elementMatched {
+		if !ok {
+			leftoverElements = append(leftoverElements, j)
+		}
+	}
+
+	return unmatchedItems, leftoverElements
+}
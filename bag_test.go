@@ -0,0 +1,49 @@
+package contesta
+
+import (
+	"testing"
+)
+
+func TestBag(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	tests := map[string]contesterTest{
+		"Bag in order":                 {[]int{1, 2, 3}, c.Bag(1, 2, 3), true},
+		"Bag out of order":             {[]int{3, 1, 2}, c.Bag(1, 2, 3), true},
+		"Bag with duplicates":          {[]int{2, 1, 1}, c.Bag(1, 1, 2), true},
+		"Bag missing a duplicate":      {[]int{1, 2}, c.Bag(1, 1, 2), false},
+		"Bag with extra duplicate":     {[]int{1, 1, 2}, c.Bag(1, 2), false},
+		"Bag with missing item":        {[]int{1, 2}, c.Bag(1, 2, 3), false},
+		"Bag with extra element":       {[]int{1, 2, 3}, c.Bag(1, 2), false},
+		"Bag of array":                 {[2]string{"b", "a"}, c.Bag("a", "b"), true},
+		"empty Bag":                    {[]int{}, c.Bag(), true},
+		"Bag with Contesters":          {[]int{5, 1}, c.Bag(c.GreaterThan(0), c.GreaterThan(3)), true},
+		"Bag needing reassignment":     {[]int{5, 1}, c.Bag(c.GreaterThan(0), 5), true},
+		"Bag with unmatched Contester": {[]int{1, 2}, c.Bag(c.GreaterThan(0), c.GreaterThan(3)), false},
+		"Bag of int":                   {42, c.Bag(42), false},
+		"Bag of nil":                   {nil, c.Bag(), false},
+		"Set in order":                 {[]int{1, 2}, c.Set(1, 2), true},
+		"Set with duplicate elements":  {[]int{2, 1, 1}, c.Set(1, 2), true},
+		"Set with duplicate items":     {[]int{2, 1}, c.Set(1, 1, 2), true},
+		"Set with missing item":        {[]int{1, 1}, c.Set(1, 2), false},
+		"Set with extra element":       {[]int{1, 2, 3}, c.Set(1, 2), false},
+		"Set with Contester":           {[]int{1, 2, 3}, c.Set(c.GreaterThan(0)), true},
+		"nested Bag":                   {map[string][]int{"a": {2, 1}}, c.Map(c.Key("a").Is(c.Bag(1, 2))), true},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("paths", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "[]int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is([]int{4, 1, 2}, c.Bag(1, 2, 3))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"[]int", "Bag[2]"}},
+				{pass: false, dataPath: []string{"[]int", "[0]"}},
+			},
+			"Bag",
+		)
+	})
+}
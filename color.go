@@ -0,0 +1,69 @@
+package contesta
+
+import (
+	"os"
+	"strings"
+
+	"github.com/houseabsolute/contesta/internal/ansi"
+	"github.com/houseabsolute/contesta/internal/term"
+)
+
+// Scheme is a set of functions used to style the output. You can use one of
+// the schemes provided by this package or create your own.
+type Scheme = ansi.Scheme
+
+var (
+	// DefaultScheme uses 256-color escape codes.
+	DefaultScheme = ansi.DefaultScheme
+	// BasicScheme only uses the 16 basic colors.
+	BasicScheme = ansi.BasicScheme
+	// PlainScheme does not use color or any other styling.
+	PlainScheme = ansi.PlainScheme
+)
+
+// SetScheme sets the scheme used to style the output for this `*C`,
+// overriding the scheme that was picked when it was created.
+func (c *C) SetScheme(s Scheme) {
+	c.scheme = s
+}
+
+// detectScheme picks a scheme for output written to `w` based on the
+// environment:
+//
+//   - If `CONTESTA_COLOR` is set, it wins. It can be "never" (or "0"), "16",
+//     "256", or "always" (or "1"). "always" uses the same scheme as a
+//     terminal would.
+//   - If `NO_COLOR` is set to anything, color is disabled.
+//   - If `TERM` is "dumb", or the output is not a terminal, color is disabled.
+//
+// Otherwise 256-color output is used if `TERM` or `COLORTERM` indicate that
+// the terminal supports it, and the basic 16 colors are used if not.
+func detectScheme(w any) Scheme {
+	switch strings.ToLower(os.Getenv("CONTESTA_COLOR")) {
+	case "never", "0", "false", "no":
+		return PlainScheme
+	case "16":
+		return BasicScheme
+	case "256":
+		return DefaultScheme
+	case "always", "1", "true", "yes":
+		return terminalScheme()
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return PlainScheme
+	}
+
+	if os.Getenv("TERM") == "dumb" || !term.IsTerminal(w) {
+		return PlainScheme
+	}
+
+	return terminalScheme()
+}
+
+func terminalScheme() Scheme {
+	if os.Getenv("COLORTERM") != "" || strings.Contains(os.Getenv("TERM"), "256color") {
+		return DefaultScheme
+	}
+	return BasicScheme
+}
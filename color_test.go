@@ -0,0 +1,94 @@
+package contesta
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectScheme(t *testing.T) {
+	tests := map[string]struct {
+		env    map[string]string
+		output any
+		expect Scheme
+	}{
+		"not a terminal": {
+			output: newMockT(),
+			expect: PlainScheme,
+		},
+		"forced on": {
+			env:    map[string]string{"CONTESTA_COLOR": "always", "TERM": "xterm-256color"},
+			output: newMockT(),
+			expect: DefaultScheme,
+		},
+		"forced on with basic terminal": {
+			env:    map[string]string{"CONTESTA_COLOR": "always", "TERM": "xterm"},
+			output: newMockT(),
+			expect: BasicScheme,
+		},
+		"forced to 16 colors": {
+			env:    map[string]string{"CONTESTA_COLOR": "16"},
+			output: newMockT(),
+			expect: BasicScheme,
+		},
+		"forced to 256 colors": {
+			env:    map[string]string{"CONTESTA_COLOR": "256"},
+			output: newMockT(),
+			expect: DefaultScheme,
+		},
+		"forced on wins over NO_COLOR": {
+			env:    map[string]string{"CONTESTA_COLOR": "256", "NO_COLOR": "1"},
+			output: newMockT(),
+			expect: DefaultScheme,
+		},
+		"forced off": {
+			env:    map[string]string{"CONTESTA_COLOR": "never"},
+			output: newMockT(),
+			expect: PlainScheme,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []string{"CONTESTA_COLOR", "NO_COLOR", "TERM", "COLORTERM"} {
+				t.Setenv(k, test.env[k])
+			}
+			got := detectScheme(test.output)
+			assert.Equal(t, test.expect.Correct("x"), got.Correct("x"))
+			assert.Equal(t, test.expect.Strong("x"), got.Strong("x"))
+		})
+	}
+
+	t.Run("NO_COLOR with a terminal", func(t *testing.T) {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			t.Skip("no terminal available")
+		}
+		defer tty.Close()
+
+		t.Setenv("CONTESTA_COLOR", "")
+		t.Setenv("NO_COLOR", "1")
+		assert.Equal(t, "x", detectScheme(tty).Correct("x"))
+	})
+}
+
+func TestSetScheme(t *testing.T) {
+	t.Setenv("CONTESTA_COLOR", "never")
+	// The TAP formatter does not use color, so we make sure that we get the
+	// default formatter.
+	t.Setenv("CONTESTA_FORMAT", "")
+
+	m := newMockT()
+	c := NewWithOutput(m, m)
+	c.Is(1, 2)
+	assert.NotContains(t, m.output(), "\033[")
+
+	m = newMockT()
+	c = NewWithOutput(m, m)
+	c.SetScheme(BasicScheme)
+	c.Is(1, 2)
+	assert.True(t, strings.Contains(m.output(), "\033[91m"), "output uses basic red")
+}
@@ -0,0 +1,73 @@
+package contesta
+
+import (
+	"fmt"
+)
+
+// CombinationTester applies several tests to the same value and combines
+// their results. Each test is pushed as its own path element, like
+// `AnyOf[1]`, so the failure output shows which branch failed.
+type CombinationTester struct {
+	kind     string
+	branches []any
+}
+
+// AllOf returns a `*CombinationTester` which passes if the actual value
+// matches every one of the expected values. Each expected value can be either
+// a literal value or a Contester.
+func (c *C) AllOf(expected ...any) *CombinationTester {
+	return &CombinationTester{kind: "AllOf", branches: expected}
+}
+
+// AnyOf returns a `*CombinationTester` which passes if the actual value
+// matches at least one of the expected values. If none of them match then
+// the failures for every branch are reported.
+func (c *C) AnyOf(expected ...any) *CombinationTester {
+	return &CombinationTester{kind: "AnyOf", branches: expected}
+}
+
+// NoneOf returns a `*CombinationTester` which passes if the actual value does
+// not match any of the expected values. Each branch which matches is reported
+// as a failure.
+func (c *C) NoneOf(expected ...any) *CombinationTester {
+	return &CombinationTester{kind: "NoneOf", branches: expected}
+}
+
+func (ct *CombinationTester) Test(c *C, actual any) []*Result {
+	if len(ct.branches) == 0 {
+		return []*Result{c.FailUsage(fmt.Sprintf("%s requires at least one test", ct.kind))}
+	}
+
+	var all []*Result
+	for i, b := range ct.branches {
+		c.PushPath(Path{data: fmt.Sprintf("%s[%d]", ct.kind, i)})
+		results := c.is(actual, b)
+		if ct.kind == "NoneOf" {
+			results = c.negate(actual, b, results)
+		}
+		c.PopPath()
+
+		if ct.kind == "AnyOf" && allPassed(results) {
+			return results
+		}
+		all = append(all, results...)
+	}
+
+	if ct.kind == "AnyOf" {
+		all = append(all, c.FailStructure(fmt.Sprintf(
+			"The value did not match any of the %d branches of AnyOf",
+			len(ct.branches),
+		)))
+	}
+
+	return all
+}
+
+func allPassed(results []*Result) bool {
+	for _, r := range results {
+		if !r.pass {
+			return false
+		}
+	}
+	return true
+}
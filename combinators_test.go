@@ -0,0 +1,107 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinators(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	tests := map[string]contesterTest{
+		"AllOf passes": {
+			actual: "foobar",
+			expect: c.AllOf(c.HasPrefix("foo"), c.Not("")),
+			pass:   true,
+		},
+		"AllOf fails": {
+			actual: "foobar",
+			expect: c.AllOf(c.HasPrefix("foo"), c.HasSuffix("baz")),
+			pass:   false,
+		},
+		"AnyOf passes": {
+			actual: 42,
+			expect: c.AnyOf(41, 42, 43),
+			pass:   true,
+		},
+		"AnyOf fails": {
+			actual: 42,
+			expect: c.AnyOf(41, c.GreaterThan(50)),
+			pass:   false,
+		},
+		"NoneOf passes": {
+			actual: 42,
+			expect: c.NoneOf(41, 43),
+			pass:   true,
+		},
+		"NoneOf fails": {
+			actual: 42,
+			expect: c.NoneOf(41, c.LessThan(50)),
+			pass:   false,
+		},
+		"no branches": {
+			actual: 42,
+			expect: c.AllOf(),
+			pass:   false,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("AnyOf paths", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is(42, c.AnyOf(41, 43))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"int", "AnyOf[0]"}},
+				{pass: false, dataPath: []string{"int", "AnyOf[1]"}},
+				{pass: false, dataPath: []string{"int"}},
+			},
+			"AnyOf",
+		)
+	})
+
+	t.Run("AnyOf description", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(42, c.AnyOf(41, 43))
+		if assert.Len(t, res, 3) {
+			assert.Equal(t, "The value did not match any of the 2 branches of AnyOf", res[2].Description())
+		}
+	})
+
+	t.Run("AllOf reports failed branches", func(t *testing.T) {
+		lines := plainOutput(t, 5, func(c *C) Contester { return c.AllOf(5, c.GreaterThan(10), 6) })
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, lines[0], "int AllOf[1]: got int 5 expected > int 10")
+			assert.Contains(t, lines[1], "int AllOf[2]: got int 5 expected == int 6")
+		}
+	})
+
+	t.Run("NoneOf reports matching branches", func(t *testing.T) {
+		lines := plainOutput(t, 5, func(c *C) Contester { return c.NoneOf(4, 5, c.GreaterThan(1)) })
+		if assert.Len(t, lines, 2) {
+			assert.Contains(t, lines[0], "int NoneOf[1]: got int 5 expected != int 5")
+			assert.Contains(t, lines[1], "int NoneOf[2]: got int 5 expected not > int 1")
+		}
+	})
+
+	t.Run("NoneOf results", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "int"})
+		res := c.is(5, c.NoneOf(4, 5))
+		if assert.Len(t, res, 2) {
+			assert.True(t, res[0].Passed())
+			assert.Equal(t, "!=", res[0].Op())
+			assert.False(t, res[1].Passed())
+			assert.Equal(t, "!=", res[1].Op())
+			expect, _ := res[1].Expect()
+			assert.Equal(t, 5, expect)
+			assert.Equal(t, "NoneOf[1]", res[1].Paths()[1].data)
+		}
+	})
+}
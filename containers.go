@@ -1,49 +1,215 @@
 package contesta
 
+import (
+	"fmt"
+	"reflect"
+)
+
 type NonExhaustiveContainerTest struct{}
 
-func (NonExhaustiveContainerTest) isMapTest() {}
+func (NonExhaustiveContainerTest) isMapTest()    {}
+func (NonExhaustiveContainerTest) isSliceTest()  {}
+func (NonExhaustiveContainerTest) isStructTest() {}
 
 func NonExhaustive() NonExhaustiveContainerTest {
 	return NonExhaustiveContainerTest{}
 }
 
 // This always passes.
-func (NonExhaustiveContainerTest) Test(_ *C, _ any) []*result {
-	return []*result{{
+func (NonExhaustiveContainerTest) Test(c *C, _ reflect.Value) []*Result {
+	return []*Result{{
 		pass:        true,
 		description: "NonExhaustiveContainerTest always passes",
+		paths:       c.Paths(),
 	}}
 }
 
 type ExhaustiveContainerTest struct{}
 
-func (ExhaustiveContainerTest) isMapTest() {}
+func (ExhaustiveContainerTest) isMapTest()    {}
+func (ExhaustiveContainerTest) isSliceTest()  {}
+func (ExhaustiveContainerTest) isStructTest() {}
 
 func End() ExhaustiveContainerTest {
 	return ExhaustiveContainerTest{}
 }
 
-func (ExhaustiveContainerTest) Test(c *C, _ any) []*result {
-	// check if value is a map and has any unchecked keys
-	if false {
-		return []*result{{
+// Test checks that every key, element, or field in the container was tested
+// by one of the other tests passed to the container tester.
+func (ExhaustiveContainerTest) Test(c *C, value reflect.Value) []*Result {
+	checked := c.checked()
+
+	var desc string
+	switch value.Kind() {
+	case reflect.Map:
+		var keys []any
+		for _, k := range sortedMapKeys(value) {
+			if !checked[k.Interface()] {
+				keys = append(keys, k.Interface())
+			}
+		}
+		if len(keys) > 0 {
+			desc = MapKeysNotCheckedFailure{keys}.Failure()
+		}
+	case reflect.Slice, reflect.Array:
+		var indexes []int
+		for i := 0; i < value.Len(); i++ {
+			if !checked[i] {
+				indexes = append(indexes, i)
+			}
+		}
+		if len(indexes) > 0 {
+			desc = SliceElementsNotCheckedFailure{indexes}.Failure()
+		}
+	case reflect.Struct:
+		fields := uncheckedFields(
+			reflect.VisibleFields(value.Type()),
+			nil,
+			checked,
+			c.container().allowUnexported,
+		)
+		if len(fields) > 0 {
+			desc = StructFieldsNotCheckedFailure{fields}.Failure()
+		}
+	}
+
+	if desc != "" {
+		return []*Result{{
 			pass:        false,
-			description: "Map has unchecked keys",
+			description: desc,
+			paths:       c.Paths(),
+			where:       inDataStructure,
 		}}
 	}
 
-	// check if value is a slice and has any unchecked elements
-	// if false {
-	// 	return SliceElementsNotCheckedFailure{}
-	// }
+	return []*Result{{
+		pass:        true,
+		description: "All values in the container were checked",
+		paths:       c.Paths(),
+	}}
+}
 
-	// check if value is an array and has any unchecked elements
+// uncheckedFields returns the names of the fields directly under the field at
+// `index` which were not tested. An embedded struct counts as checked if it was
+// tested directly or if all of its promoted fields were tested. Otherwise its
+// untested promoted fields are returned instead of its own name.
+func uncheckedFields(
+	visible []reflect.StructField,
+	index []int,
+	checked map[any]bool,
+	allowUnexported bool,
+) []string {
+	var fields []string
+	for _, f := range visible {
+		if !isChildIndex(f.Index, index) || checked[f.Name] {
+			continue
+		}
 
-	// check if value is a struct and has any unchecked fields
+		if f.Anonymous && derefType(f.Type).Kind() == reflect.Struct {
+			promoted := uncheckedFields(visible, f.Index, checked, allowUnexported)
+			if len(promoted) > 0 || hasChildFields(visible, f.Index, allowUnexported) {
+				fields = append(fields, promoted...)
+				continue
+			}
+		}
 
-	return []*result{{
-		pass:        true,
-		description: "All values in the container were checked",
+		if f.IsExported() || allowUnexported {
+			fields = append(fields, f.Name)
+		}
+	}
+
+	return fields
+}
+
+func hasChildFields(visible []reflect.StructField, index []int, allowUnexported bool) bool {
+	for _, f := range visible {
+		if isChildIndex(f.Index, index) && (f.IsExported() || allowUnexported || f.Anonymous) {
+			return true
+		}
+	}
+	return false
+}
+
+// isChildIndex returns true if `index` is the index of a field directly under
+// the field at `parent`.
+func isChildIndex(index, parent []int) bool {
+	if len(index) != len(parent)+1 {
+		return false
+	}
+	for i, p := range parent {
+		if index[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func derefType(ty reflect.Type) reflect.Type {
+	for ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return ty
+}
+
+func isContainerTerminator(test any) bool {
+	switch test.(type) {
+	case ExhaustiveContainerTest, NonExhaustiveContainerTest:
+		return true
+	}
+	return false
+}
+
+// containerState tracks the keys, indexes, or fields that have been tested in
+// the container currently being tested.
+type containerState struct {
+	checked         map[any]bool
+	allowUnexported bool
+}
+
+func (c *C) pushContainer() {
+	c.state.containers = append(c.state.containers, &containerState{checked: map[any]bool{}})
+}
+
+func (c *C) popContainer() {
+	if len(c.state.containers) > 0 {
+		c.state.containers = c.state.containers[:len(c.state.containers)-1]
+	}
+}
+
+// markChecked records that the given key, index, or field name was tested in
+// the current container.
+func (c *C) markChecked(key any) {
+	if len(c.state.containers) > 0 {
+		c.state.containers[len(c.state.containers)-1].checked[key] = true
+	}
+}
+
+func (c *C) checked() map[any]bool {
+	return c.container().checked
+}
+
+// container returns the state for the container currently being tested. If
+// there is no such container it returns an empty state.
+func (c *C) container() *containerState {
+	if len(c.state.containers) == 0 {
+		return &containerState{checked: map[any]bool{}}
+	}
+	return c.state.containers[len(c.state.containers)-1]
+}
+
+// unexpectedKindResult returns a failure for a container tester that was
+// given a value of the wrong kind. The `expected` argument should include an
+// article, like "a map".
+func unexpectedKindResult(c *C, expected string, actualType reflect.Type) []*Result {
+	got := "nil"
+	if actualType != nil {
+		got = articleize(actualType.Kind().String())
+	}
+
+	return []*Result{{
+		pass:        false,
+		description: fmt.Sprintf("Expected %s but got %s", expected, got),
+		paths:       c.Paths(),
+		where:       inType,
 	}}
 }
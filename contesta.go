@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 	"reflect"
 
-	"github.com/houseabsolute/contesta/internal/ansi"
 	"github.com/jedib0t/go-pretty/v6/table"
 )
 
@@ -16,7 +15,7 @@ import (
 //     Map(
 //        Key("foo").Is(42),
 //        Key("bar").Is(
-//            Array(
+//            Slice(
 //                Next().Is("hi"),
 //                Next().Is("ho"),
 //            )
@@ -32,17 +31,29 @@ type C struct {
 	callerPackageRoot string
 	state             *state
 	output            StringWriter
+	scheme            Scheme
+	formatter         Formatter
+	fatal             bool
 }
 
 type state struct {
-	output []outputItem
-	actual []any
-	paths  []Path
-	caller *string
+	output     []outputItem
+	actual     []any
+	paths      []Path
+	callers    []callerOverride
+	containers []*containerState
+}
+
+// callerOverride records a caller set with `SetCaller` along with the depth of
+// the path stack at the time it was set, so that nested testers only override
+// the caller for their own path element.
+type callerOverride struct {
+	depth  int
+	caller string
 }
 
 type outputItem struct {
-	result  *result
+	result  *Result
 	warning string
 }
 
@@ -60,12 +71,32 @@ type StringWriter interface {
 }
 
 // Contester is the interface for anything that implements the `Test` method.
+//
+// You can implement your own Contesters outside of this package. A Contester's
+// `Test` method must return at least one `*Result`. Results should be created
+// with `c.Pass`, `c.FailValue`, `c.FailType`, `c.FailStructure`, or
+// `c.FailUsage`, which attach the current data path from `c.Paths()`.
+//
+// A Contester which tests a value nested inside the value it was given, like a
+// field or an element, should push a path element for it with
+// `c.PushPath(c.NewPath(...))`, then pass the nested value and the expected
+// value or Contester to `c.Apply`, then call `c.PopPath()`. The results from
+// `c.Apply` can be returned as-is or inspected with `Passed()`.
+//
+// Call `contesta.RegisterPackage()` from your package's `init` func so that
+// calls from your package are shown as function names in the failure output.
 type Contester interface {
-	Test(c *C, value any) []*result
+	Test(c *C, value any) []*Result
 }
 
 // New takes any implementer of the `TestingT` interface and returns a new
 // `*contesta.C`. A `*C` created this way will send its output to `os.Stdout`.
+//
+// Color is used if `os.Stdout` is a terminal, unless the `NO_COLOR`
+// environment variable is set. You can set `CONTESTA_COLOR` to "always",
+// "never", "16", or "256" to override this, or call `c.SetScheme`. The output
+// format is picked using the `CONTESTA_FORMAT` environment variable, as
+// described for `Formatter`.
 func New(t TestingT) *C {
 	return &C{
 		t: t,
@@ -74,15 +105,18 @@ func New(t TestingT) *C {
 		// convert the root to the forward slash version.
 		callerPackageRoot: filepath.ToSlash(filepath.Dir(findFrame(1).File)),
 		output:            os.Stdout,
+		scheme:            detectScheme(os.Stdout),
+		formatter:         formatterFromEnv(),
 	}
 }
 
 // NewWithOutput takes any implementer of the `TestingT` interface and a
 // `StringWriter` implementer and returns a new `*contesta.C`. This is
 // provided primarily for the benefit of testing code that wants to capture
-// the output from contesta.
+// the output from contesta. Color and the output format are picked using the
+// same rules as `New`.
 func NewWithOutput(t TestingT, o StringWriter) *C {
-	return &C{t: t, output: o}
+	return &C{t: t, output: o, scheme: detectScheme(o), formatter: formatterFromEnv()}
 }
 
 // Is tests that two variables are exactly equal. The first variable is the
@@ -107,6 +141,25 @@ func (c *C) Is(actual, expected any, args ...any) bool {
 	return c.processResults(c.is(actual, expected), "Is", args)
 }
 
+// Isnt tests that two variables are not exactly equal. The `unexpected`
+// argument can be either a literal value or anything that implements the
+// `contesta.Contester` interface, in which case the Contester's results are
+// inverted.
+//
+// The final arguments follow the same rules as `c.Is`.
+//
+// Under the hood this is implemented using a `NotTester`.
+func (c *C) Isnt(actual, unexpected any, args ...any) bool {
+	c.t.Helper()
+	c.ResetState()
+
+	actualType := reflect.TypeOf(actual)
+	c.PushPath(c.NewPath(describeType(actualType), 0, "contesta.(*C).Isnt"))
+	defer c.PopPath()
+
+	return c.processResults(c.is(actual, c.Not(unexpected)), "Isnt", args)
+}
+
 // ValueIs tests that two variables contain the same value. The first variable
 // is the actual variable and the second is what is expected.
 //
@@ -114,7 +167,8 @@ func (c *C) Is(actual, expected any, args ...any) bool {
 //
 // If the two variables to be compared are of different types this is fine as
 // long as one type can be converted to the other (for example `int32` and
-// `int64`).
+// `int64`). The same rules apply to the contents of slices, arrays, maps,
+// pointers, and structs, so `[]int32{1}` is equal to `[]int{1}`.
 //
 // Under the hood this is implemented using a `ValueEqualityTester`.
 func (c *C) ValueIs(actual, expect any, args ...any) bool {
@@ -126,7 +180,7 @@ func (c *C) ValueIs(actual, expect any, args ...any) bool {
 	defer c.PopPath()
 
 	if _, ok := expect.(Contester); ok {
-		return c.processResults([]*result{{
+		return c.processResults([]*Result{{
 			pass:   false,
 			actual: newValue(actual),
 			expect: newValue(expect),
@@ -141,20 +195,40 @@ func (c *C) ValueIs(actual, expect any, args ...any) bool {
 	return c.processResults(vet.Test(c, actual), "ValueIs", args)
 }
 
-func (c *C) processResults(results []*result, method string, args []any) bool {
+// Require returns a view of the `*C` where every failed assertion stops the
+// test by calling `Fatal` on the `TestingT` after the failure output has been
+// written. This is useful for setup steps where later assertions would be
+// meaningless, or would panic, if the step failed:
+//
+//	c.Require().Is(resp != nil, true, "decoded response is not nil")
+//
+// The returned `*C` shares its output and caller package with the original.
+func (c *C) Require() *C {
+	r := *c
+	r.fatal = true
+	return &r
+}
+
+func (c *C) processResults(results []*Result, method string, args []any) bool {
+	name := argsToName(method, args)
+
 	passed := true
 	for _, r := range results {
-		c.output.WriteString(r.describe(argsToName(method, args), ansi.DefaultScheme))
 		if !r.pass {
 			c.t.Fail()
 			passed = false
 		}
 	}
+	c.output.WriteString(c.formatter.Format(name, results, c.scheme))
+
+	if !passed && c.fatal {
+		c.t.Fatal(fmt.Sprintf("Required assertion failed: %s", name))
+	}
 
 	return passed
 }
 
-func maybeNot(r *result) string {
+func maybeNot(r *Result) string {
 	if r.pass {
 		return "   "
 	}
@@ -168,7 +242,15 @@ func (c *C) ResetState() {
 	c.state = &state{}
 }
 
-func (c *C) is(actual, expected any) []*result {
+// Apply tests the actual value against the expected value, which may be
+// either a literal value or a Contester. A literal value is compared using an
+// `ExactEqualityTester`. This is intended for use by Contesters which test
+// nested values.
+func (c *C) Apply(actual, expected any) []*Result {
+	return c.is(actual, expected)
+}
+
+func (c *C) is(actual, expected any) []*Result {
 	if e, ok := expected.(Contester); ok {
 		return e.Test(c, actual)
 	}
@@ -176,12 +258,15 @@ func (c *C) is(actual, expected any) []*result {
 	return eet.Test(c, actual)
 }
 
-// Paths returns the current paths, with the caller overriden by the last
-// caller if one was set.
+// Paths returns a copy of the current paths, with the caller of each path
+// overridden by the caller set for it with `SetCaller`, if any.
 func (c *C) Paths() []Path {
-	paths := c.state.paths
-	if c.state.caller != nil {
-		paths[len(paths)-1].caller = *c.state.caller
+	paths := make([]Path, len(c.state.paths))
+	copy(paths, c.state.paths)
+	for _, o := range c.state.callers {
+		if o.depth > 0 && o.depth <= len(paths) {
+			paths[o.depth-1].caller = o.caller
+		}
 	}
 	return paths
 }
@@ -198,14 +283,19 @@ func (c *C) PopPath() {
 	}
 }
 
-// SetCaller adds a caller that overrides the current path.
+// SetCaller adds a caller that overrides the caller of the current path.
 func (c *C) SetCaller(caller string) {
-	c.state.caller = &caller
+	c.state.callers = append(
+		c.state.callers,
+		callerOverride{depth: len(c.state.paths), caller: caller},
+	)
 }
 
-// PopPath removes the top path from the current path stack.
+// UnsetCaller removes the caller most recently added with `SetCaller`.
 func (c *C) UnsetCaller() {
-	c.state.caller = nil
+	if len(c.state.callers) > 0 {
+		c.state.callers = c.state.callers[:len(c.state.callers)-1]
+	}
 }
 
 func argsToName(defaultName string, args []any) string {
@@ -225,7 +315,7 @@ func argsToName(defaultName string, args []any) string {
 	return format
 }
 
-func (c *C) ok(results []*result, name string) bool {
+func (c *C) ok(results []*Result, name string) bool {
 	pass, err := c.renderOutput(results, name)
 	if err != nil {
 		panic(err)
@@ -234,9 +324,9 @@ func (c *C) ok(results []*result, name string) bool {
 	return pass
 }
 
-func (c *C) renderOutput(results []*result, name string) (bool, error) {
+func (c *C) renderOutput(results []*Result, name string) (bool, error) {
 	pass := true
-	scheme := ansi.DefaultScheme
+	scheme := c.scheme
 
 	var warnings []string
 	for _, r := range results {
@@ -292,7 +382,11 @@ func (c *C) renderOutput(results []*result, name string) (bool, error) {
 }
 
 // CalledAt returns a string describing the function, file, and line for this
-// path element.
+// path element. Path elements which were found while walking a data structure
+// have no caller, in which case this returns an empty string.
 func (p Path) CalledAt() string {
+	if p.caller == "" && p.callee == "" {
+		return ""
+	}
 	return fmt.Sprintf("%s called %s", p.caller, p.callee)
 }
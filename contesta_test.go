@@ -22,3 +22,26 @@ func TestIs(t *testing.T) {
 		})
 	})
 }
+
+func TestRequire(t *testing.T) {
+	t.Run("passing assertion", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Require().Is(42, 42)
+		m.AssertPassed(t)
+	})
+	t.Run("failing assertion", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Require().ValueIs(42, 43, "answer")
+		m.AssertFailed(t)
+		m.AssertCalled(t, "Fatal", []interface{}{"Required assertion failed: answer"})
+	})
+	t.Run("original is not fatal", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Require()
+		c.Is(42, 43)
+		m.AssertNotCalled(t, "Fatal")
+	})
+}
@@ -38,6 +38,14 @@ func TestIs(t *testing.T) {
 		),
 	)
 
+	c.Is(
+		[]string{"hi", "ho"},
+		c.Slice(
+			c.Next().Is("hi"),
+			c.Next().Is("hey"),
+		),
+	)
+
 	c.ValueIs(42, 42)
 	c.ValueIs(42, 42.0)
 	c.ValueIs(42, 43)
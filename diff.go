@@ -0,0 +1,490 @@
+package contesta
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// differ walks two values in parallel, pushing a path element for each map
+// key, slice or array index, and struct field that it descends into. It
+// records a result for every leaf where the two values differ.
+//
+// When `convert` is true, values of different types are compared using the
+// same conversion rules as `c.ValueIs`, and containers of different types are
+// walked by kind, so a `[]int32` can be compared to a `[]int`.
+type differ struct {
+	c       *C
+	op      string
+	convert bool
+	visited map[visit]bool
+	results []*Result
+}
+
+// visit records a pair of references that the differ has already descended
+// into, so that cyclic data structures do not cause infinite recursion.
+type visit struct {
+	actual     uintptr
+	expect     uintptr
+	actualType reflect.Type
+	expectType reflect.Type
+	actualLen  int
+	expectLen  int
+}
+
+// diff compares the actual and expected values and returns a failed result
+// for each difference it finds. If the values are equal then it returns an
+// empty slice. The `op` is used as the operator for each result.
+func (c *C) diff(actual, expect any, op string) []*Result {
+	d := &differ{
+		c:       c,
+		op:      op,
+		visited: map[visit]bool{},
+	}
+	d.walk(reflect.ValueOf(actual), reflect.ValueOf(expect))
+	return d.results
+}
+
+// valueDiff is like `diff` but compares values of different types by value,
+// converting numbers element by element as `c.ValueIs` does.
+func (c *C) valueDiff(actual, expect any, op string) []*Result {
+	d := &differ{
+		c:       c,
+		op:      op,
+		convert: true,
+		visited: map[visit]bool{},
+	}
+	d.walk(reflect.ValueOf(actual), reflect.ValueOf(expect))
+	return d.results
+}
+
+func (d *differ) walk(actual, expect reflect.Value) {
+	if !actual.IsValid() || !expect.IsValid() {
+		if d.convert {
+			d.walkConverted(actual, expect)
+		} else if actual.IsValid() != expect.IsValid() {
+			d.fail(actual, expect, inValue)
+		}
+		return
+	}
+
+	if actual.Type() != expect.Type() {
+		if d.convert {
+			d.walkConverted(actual, expect)
+		} else {
+			d.fail(actual, expect, inType)
+		}
+		return
+	}
+
+	if d.seen(actual, expect) {
+		return
+	}
+
+	// nolint: exhaustive
+	switch actual.Kind() {
+	case reflect.Array:
+		for i := 0; i < actual.Len(); i++ {
+			d.walkIndex(actual, expect, i)
+		}
+	case reflect.Slice:
+		d.walkSlice(actual, expect)
+	case reflect.Interface:
+		if actual.IsNil() || expect.IsNil() {
+			if actual.IsNil() != expect.IsNil() {
+				d.fail(actual, expect, inValue)
+			}
+			return
+		}
+		d.walk(actual.Elem(), expect.Elem())
+	case reflect.Ptr:
+		if actual.Pointer() == expect.Pointer() {
+			return
+		}
+		if actual.IsNil() || expect.IsNil() {
+			d.fail(actual, expect, inValue)
+			return
+		}
+		d.walk(actual.Elem(), expect.Elem())
+	case reflect.Struct:
+		for i := 0; i < actual.NumField(); i++ {
+			d.c.PushPath(Path{data: "." + actual.Type().Field(i).Name})
+			d.walk(actual.Field(i), expect.Field(i))
+			d.c.PopPath()
+		}
+	case reflect.Map:
+		d.walkMap(actual, expect)
+	case reflect.Func:
+		// Like reflect.DeepEqual, functions are only equal if both are nil.
+		if !actual.IsNil() || !expect.IsNil() {
+			d.fail(actual, expect, inValue)
+		}
+	default:
+		if !leavesAreEqual(actual, expect) {
+			d.fail(actual, expect, inValue)
+		}
+	}
+}
+
+func (d *differ) walkIndex(actual, expect reflect.Value, i int) {
+	d.c.PushPath(Path{data: fmt.Sprintf("[%d]", i)})
+	defer d.c.PopPath()
+	d.walk(actual.Index(i), expect.Index(i))
+}
+
+func (d *differ) walkSlice(actual, expect reflect.Value) {
+	if actual.IsNil() != expect.IsNil() {
+		d.fail(actual, expect, inValue)
+		return
+	}
+
+	// Byte slices are treated as a single value rather than comparing (and
+	// reporting) each byte individually.
+	if actual.Type().Elem().Kind() == reflect.Uint8 {
+		if !bytes.Equal(actual.Bytes(), expect.Bytes()) {
+			d.fail(actual, expect, inValue)
+		}
+		return
+	}
+
+	d.walkElements(actual, expect)
+}
+
+// walkElements compares the elements of two slices or arrays, which may have
+// different lengths.
+func (d *differ) walkElements(actual, expect reflect.Value) {
+	shared := actual.Len()
+	if expect.Len() < shared {
+		shared = expect.Len()
+	}
+	for i := 0; i < shared; i++ {
+		d.walkIndex(actual, expect, i)
+	}
+
+	for i := shared; i < actual.Len(); i++ {
+		d.c.PushPath(Path{data: fmt.Sprintf("[%d]", i)})
+		d.missing(
+			actual.Index(i), reflect.Value{},
+			"This element does not exist in the expected slice",
+		)
+		d.c.PopPath()
+	}
+	for i := shared; i < expect.Len(); i++ {
+		d.c.PushPath(Path{data: fmt.Sprintf("[%d]", i)})
+		d.missing(
+			reflect.Value{}, expect.Index(i),
+			"This element does not exist in the actual slice",
+		)
+		d.c.PopPath()
+	}
+}
+
+func (d *differ) walkMap(actual, expect reflect.Value) {
+	if actual.IsNil() != expect.IsNil() {
+		d.fail(actual, expect, inValue)
+		return
+	}
+
+	for _, k := range sortedMapKeys(actual) {
+		d.c.PushPath(Path{data: keyPathData(k)})
+		ev := expect.MapIndex(k)
+		if ev.IsValid() {
+			d.walk(actual.MapIndex(k), ev)
+		} else {
+			d.missing(
+				actual.MapIndex(k), reflect.Value{},
+				"This key does not exist in the expected map",
+			)
+		}
+		d.c.PopPath()
+	}
+
+	for _, k := range sortedMapKeys(expect) {
+		if actual.MapIndex(k).IsValid() {
+			continue
+		}
+		d.c.PushPath(Path{data: keyPathData(k)})
+		d.missing(
+			reflect.Value{}, expect.MapIndex(k),
+			"This key does not exist in the actual map",
+		)
+		d.c.PopPath()
+	}
+}
+
+// walkConverted compares two values of different types. Numbers are converted
+// with `safelyConvertNumberTypes`, and containers of the same kind are walked
+// element by element. A nil value is equal to any other nil value, regardless
+// of type.
+func (d *differ) walkConverted(actual, expect reflect.Value) {
+	actual = interfaceElem(actual)
+	expect = interfaceElem(expect)
+
+	if isNilValue(actual) || isNilValue(expect) {
+		if isNilValue(actual) != isNilValue(expect) {
+			d.fail(actual, expect, inValue)
+		}
+		return
+	}
+
+	if actual.Type() == expect.Type() {
+		d.walk(actual, expect)
+		return
+	}
+
+	actualNumeric := isNumeric(actual)
+	expectNumeric := isNumeric(expect)
+	if actualNumeric != nil && expectNumeric != nil {
+		a, e, desc := safelyConvertNumberTypes(actual, expect, actualNumeric, expectNumeric)
+		if desc != "" {
+			d.fail(actual, expect, inType).WithDescription(desc)
+		} else if !leavesAreEqual(a, e) {
+			d.fail(actual, expect, inValue)
+		}
+		return
+	}
+
+	ak := actual.Kind()
+	ek := expect.Kind()
+	switch {
+	case (ak == reflect.Slice || ak == reflect.Array) && (ek == reflect.Slice || ek == reflect.Array):
+		if ak == reflect.Slice && ek == reflect.Slice && actual.IsNil() != expect.IsNil() {
+			d.fail(actual, expect, inValue)
+			return
+		}
+		if !d.seen(actual, expect) {
+			d.walkElements(actual, expect)
+		}
+	case ak == reflect.Map && ek == reflect.Map:
+		if !d.seen(actual, expect) {
+			d.walkConvertedMap(actual, expect)
+		}
+	case ak == reflect.Ptr && ek == reflect.Ptr:
+		if !d.seen(actual, expect) {
+			d.walk(actual.Elem(), expect.Elem())
+		}
+	case ak == reflect.Struct && ek == reflect.Struct && sameFieldNames(actual.Type(), expect.Type()):
+		for i := 0; i < actual.NumField(); i++ {
+			d.c.PushPath(Path{data: "." + actual.Type().Field(i).Name})
+			d.walk(actual.Field(i), expect.Field(i))
+			d.c.PopPath()
+		}
+	case ak == ek && (ak == reflect.String || ak == reflect.Bool):
+		if !leavesAreEqual(actual.Convert(expect.Type()), expect) {
+			d.fail(actual, expect, inValue)
+		}
+	default:
+		d.fail(actual, expect, inType).WithDescription(
+			cannotConvertMessage(actual.Type(), expect.Type()),
+		)
+	}
+}
+
+// walkConvertedMap compares two maps of different types. Keys are matched by
+// converting each key to the other map's key type.
+func (d *differ) walkConvertedMap(actual, expect reflect.Value) {
+	if actual.IsNil() != expect.IsNil() {
+		d.fail(actual, expect, inValue)
+		return
+	}
+
+	for _, k := range sortedMapKeys(actual) {
+		d.c.PushPath(Path{data: keyPathData(k)})
+		var ev reflect.Value
+		if ek, ok := convertKey(k, expect.Type().Key()); ok {
+			ev = expect.MapIndex(ek)
+		}
+		if ev.IsValid() {
+			d.walk(actual.MapIndex(k), ev)
+		} else {
+			d.missing(
+				actual.MapIndex(k), reflect.Value{},
+				"This key does not exist in the expected map",
+			)
+		}
+		d.c.PopPath()
+	}
+
+	for _, k := range sortedMapKeys(expect) {
+		if ak, ok := convertKey(k, actual.Type().Key()); ok && actual.MapIndex(ak).IsValid() {
+			continue
+		}
+		d.c.PushPath(Path{data: keyPathData(k)})
+		d.missing(
+			reflect.Value{}, expect.MapIndex(k),
+			"This key does not exist in the actual map",
+		)
+		d.c.PopPath()
+	}
+}
+
+// convertKey converts a map key to another key type. The second return value
+// is false if the key cannot be converted without changing its value.
+func convertKey(key reflect.Value, ty reflect.Type) (reflect.Value, bool) {
+	if key.Type() == ty {
+		return key, true
+	}
+	if ty.Kind() == reflect.Interface {
+		return key, key.Type().Implements(ty)
+	}
+
+	if isOrderedNumber(key) && isOrderedNumber(reflect.Zero(ty)) {
+		return convertNumber(key, ty)
+	}
+	if isNumeric(key) != nil && isNumeric(reflect.Zero(ty)) != nil {
+		if !key.CanConvert(ty) {
+			return key, false
+		}
+		converted := key.Convert(ty)
+		return converted, leavesAreEqual(converted.Convert(key.Type()), key)
+	}
+
+	if key.Kind() == ty.Kind() && key.Kind() == reflect.String {
+		return key.Convert(ty), true
+	}
+
+	return key, false
+}
+
+// sameFieldNames returns true if the two struct types have the same fields in
+// the same order.
+func sameFieldNames(a, b reflect.Type) bool {
+	if a.NumField() != b.NumField() {
+		return false
+	}
+	for i := 0; i < a.NumField(); i++ {
+		if a.Field(i).Name != b.Field(i).Name {
+			return false
+		}
+	}
+	return true
+}
+
+func interfaceElem(v reflect.Value) reflect.Value {
+	if v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		return v.Elem()
+	}
+	return v
+}
+
+func isNilValue(v reflect.Value) bool {
+	return !v.IsValid() || (isNilable(v.Kind()) && v.IsNil())
+}
+
+// seen returns true if the differ has already started comparing these two
+// values. This is only checked for kinds which can be part of a cycle. When
+// the differ is converting values, the two values may be of different types
+// and kinds, for example a slice and an array.
+func (d *differ) seen(actual, expect reflect.Value) bool {
+	if !canCycle(actual) || !canCycle(expect) {
+		return false
+	}
+
+	if actual.IsNil() || expect.IsNil() {
+		return false
+	}
+
+	v := visit{
+		actual:     actual.Pointer(),
+		expect:     expect.Pointer(),
+		actualType: actual.Type(),
+		expectType: expect.Type(),
+	}
+	if actual.Kind() == reflect.Slice {
+		v.actualLen = actual.Len()
+	}
+	if expect.Kind() == reflect.Slice {
+		v.expectLen = expect.Len()
+	}
+
+	if d.visited[v] {
+		return true
+	}
+	d.visited[v] = true
+
+	return false
+}
+
+func canCycle(v reflect.Value) bool {
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		return true
+	}
+	return false
+}
+
+// fail records a failure for two values and returns the result so that the
+// caller can add a description.
+func (d *differ) fail(actual, expect reflect.Value, where failure) *Result {
+	res := &Result{
+		pass:     false,
+		actual:   displayValue(actual),
+		expect:   displayValue(expect),
+		op:       d.op,
+		paths:    d.c.Paths(),
+		where:    where,
+		mismatch: where == inType,
+	}
+	d.results = append(d.results, res)
+	return res
+}
+
+// missing records a failure for a map key or slice element which only exists
+// in one of the two values. The value which does not exist should be passed as
+// an invalid `reflect.Value`.
+func (d *differ) missing(actual, expect reflect.Value, desc string) {
+	res := &Result{
+		pass:        false,
+		paths:       d.c.Paths(),
+		where:       inDataStructure,
+		description: desc,
+	}
+	if actual.IsValid() {
+		res.actual = displayValue(actual)
+	}
+	if expect.IsValid() {
+		res.expect = displayValue(expect)
+	}
+	d.results = append(d.results, res)
+}
+
+// leavesAreEqual compares two values of the same type which cannot contain
+// other values. This works even when the values were found in unexported
+// struct fields, where calling `Interface()` would panic.
+func leavesAreEqual(actual, expect reflect.Value) bool {
+	// nolint: exhaustive
+	switch actual.Kind() {
+	case reflect.Bool:
+		return actual.Bool() == expect.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return actual.Int() == expect.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return actual.Uint() == expect.Uint()
+	case reflect.Float32, reflect.Float64:
+		return actual.Float() == expect.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return actual.Complex() == expect.Complex()
+	case reflect.String:
+		return actual.String() == expect.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return actual.Pointer() == expect.Pointer()
+	}
+
+	panic(fmt.Sprintf("Should never get here - compare two values of kind %s", actual.Kind()))
+}
+
+// displayValue wraps a `reflect.Value` for display in a result. If the value
+// cannot be turned back into an interface, because it was found in an
+// unexported struct field, then the `reflect.Value` itself is stored, which
+// `fmt` knows how to print.
+func displayValue(v reflect.Value) *value {
+	if !v.IsValid() {
+		return newValue(nil)
+	}
+	if v.CanInterface() {
+		return newValue(v.Interface())
+	}
+	return &value{value: v, desc: describeType(v.Type())}
+}
@@ -0,0 +1,278 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	Name     string
+	Children []*node
+	Parent   *node
+	secret   int
+}
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		actual any
+		expect any
+		paths  [][]string
+	}{
+		"equal scalars": {
+			actual: 42,
+			expect: 42,
+		},
+		"different scalars": {
+			actual: 42,
+			expect: 43,
+			paths:  [][]string{{}},
+		},
+		"nested map": {
+			actual: map[string]map[string]int{"foo": {"bar": 42, "baz": 1}},
+			expect: map[string]map[string]int{"foo": {"bar": 43, "baz": 1}},
+			paths:  [][]string{{`["foo"]`, `["bar"]`}},
+		},
+		"map keys only in one map": {
+			actual: map[int]int{1: 1, 2: 2},
+			expect: map[int]int{1: 1, 3: 3},
+			paths:  [][]string{{"[2]"}, {"[3]"}},
+		},
+		"slices of different lengths": {
+			actual: []string{"a", "b", "c"},
+			expect: []string{"a", "x"},
+			paths:  [][]string{{"[1]"}, {"[2]"}},
+		},
+		"byte slices": {
+			actual: []byte("foo"),
+			expect: []byte("bar"),
+			paths:  [][]string{{}},
+		},
+		"structs with unexported fields": {
+			actual: node{Name: "a", secret: 1},
+			expect: node{Name: "b", secret: 2},
+			paths:  [][]string{{".Name"}, {".secret"}},
+		},
+		"interfaces holding different types": {
+			actual: []any{1, "x"},
+			expect: []any{1, 2},
+			paths:  [][]string{{"[1]"}},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			c := NewWithOutput(newMockT(), newMockT())
+			c.ResetState()
+			res := c.diff(test.actual, test.expect, "==")
+			paths := [][]string{}
+			for _, r := range res {
+				assert.False(t, r.pass, "each diff result is a failure")
+				p := []string{}
+				for _, e := range r.paths {
+					p = append(p, e.data)
+				}
+				paths = append(paths, p)
+			}
+			if test.paths == nil {
+				test.paths = [][]string{}
+			}
+			assert.Equal(t, test.paths, paths)
+		})
+	}
+
+	t.Run("cycles", func(t *testing.T) {
+		makeTree := func(childName string) *node {
+			root := &node{Name: "root"}
+			child := &node{Name: childName, Parent: root}
+			root.Children = []*node{child}
+			return root
+		}
+
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		assert.Empty(t, c.diff(makeTree("child"), makeTree("child"), "=="))
+
+		res := c.diff(makeTree("child"), makeTree("kid"), "==")
+		if assert.Len(t, res, 1) {
+			p := []string{}
+			for _, e := range res[0].paths {
+				p = append(p, e.data)
+			}
+			assert.Equal(t, []string{".Children", "[0]", ".Name"}, p)
+		}
+	})
+}
+
+type otherNode struct {
+	Name     string
+	Children []*otherNode
+	Parent   *otherNode
+	secret   int64
+}
+
+func TestValueDiff(t *testing.T) {
+	var nilPtr *int
+
+	tests := map[string]struct {
+		actual any
+		expect any
+		paths  [][]string
+	}{
+		"numbers": {
+			actual: int32(42),
+			expect: 42,
+		},
+		"different numbers": {
+			actual: int32(42),
+			expect: 43,
+			paths:  [][]string{{}},
+		},
+		"slices": {
+			actual: []int32{1, 2},
+			expect: []int{1, 2},
+		},
+		"slices with a different element": {
+			actual: []int32{1, 2, 3},
+			expect: []int{1, 5, 3},
+			paths:  [][]string{{"[1]"}},
+		},
+		"slices of different lengths": {
+			actual: []int32{1, 2, 3},
+			expect: []int{1},
+			paths:  [][]string{{"[1]"}, {"[2]"}},
+		},
+		"slice and array": {
+			actual: []uint8{1, 2},
+			expect: [2]int{1, 2},
+		},
+		"maps": {
+			actual: map[string]int64{"a": 1, "b": 2},
+			expect: map[string]int{"a": 1, "b": 2},
+		},
+		"maps with different values": {
+			actual: map[string]int64{"a": 1, "b": 2},
+			expect: map[string]int{"a": 1, "b": 3},
+			paths:  [][]string{{`["b"]`}},
+		},
+		"maps with converted keys": {
+			actual: map[int8]float32{1: 1.5},
+			expect: map[int]float64{1: 1.5, 2: 2},
+			paths:  [][]string{{"[2]"}},
+		},
+		"maps with keys that change when converted": {
+			actual: map[int8]string{-1: "a"},
+			expect: map[uint8]string{255: "a"},
+			paths:  [][]string{{"[-1]"}, {"[255]"}},
+		},
+		"nested containers": {
+			actual: map[string][]int8{"a": {1, 2}},
+			expect: map[string][]int{"a": {1, 3}},
+			paths:  [][]string{{`["a"]`, "[1]"}},
+		},
+		"slice of interfaces": {
+			actual: []any{int8(1), "x"},
+			expect: []int{1, 2},
+			paths:  [][]string{{"[1]"}},
+		},
+		"structs with the same fields": {
+			actual: node{Name: "a", secret: 1},
+			expect: otherNode{Name: "a", secret: 2},
+			paths:  [][]string{{".secret"}},
+		},
+		"pointers": {
+			actual: &node{Name: "a", Children: []*node{{Name: "b"}}},
+			expect: &otherNode{Name: "a", Children: []*otherNode{{Name: "c"}}},
+			paths:  [][]string{{".Children", "[0]", ".Name"}},
+		},
+		"typed nils": {
+			actual: []any{nil},
+			expect: []*int{nilPtr},
+		},
+		"overflow": {
+			actual: []int8{-1},
+			expect: []uint8{255},
+			paths:  [][]string{{"[0]"}},
+		},
+		"types which cannot be converted": {
+			actual: []string{"1"},
+			expect: []int{1},
+			paths:  [][]string{{"[0]"}},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			c := NewWithOutput(newMockT(), newMockT())
+			c.ResetState()
+			res := c.valueDiff(test.actual, test.expect, "== (value)")
+			paths := [][]string{}
+			for _, r := range res {
+				assert.False(t, r.pass, "each diff result is a failure")
+				p := []string{}
+				for _, e := range r.paths {
+					p = append(p, e.data)
+				}
+				paths = append(paths, p)
+			}
+			if test.paths == nil {
+				test.paths = [][]string{}
+			}
+			assert.Equal(t, test.paths, paths)
+		})
+	}
+
+	t.Run("cycles", func(t *testing.T) {
+		type selfMap map[string]any
+		type selfSlice []any
+
+		makeMaps := func(leaf int) (selfMap, map[string]any) {
+			a := selfMap{"leaf": int32(1)}
+			a["self"] = a
+			e := map[string]any{"leaf": leaf}
+			e["self"] = e
+			return a, e
+		}
+		makeSlices := func(leaf int) (selfSlice, []any) {
+			a := selfSlice{int32(1), nil}
+			a[1] = a
+			e := []any{leaf, nil}
+			e[1] = e
+			return a, e
+		}
+
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+
+		a, e := makeMaps(1)
+		assert.Empty(t, c.valueDiff(a, e, "== (value)"))
+		a, e = makeMaps(2)
+		res := c.valueDiff(a, e, "== (value)")
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, []string{`["leaf"]`}, res[0].pathData())
+		}
+
+		as, es := makeSlices(1)
+		assert.Empty(t, c.valueDiff(as, es, "== (value)"))
+		as, es = makeSlices(2)
+		res = c.valueDiff(as, es, "== (value)")
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, []string{"[0]"}, res[0].pathData())
+		}
+	})
+
+	t.Run("ValueIs", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.ValueIs(map[string]int64{"a": 1}, map[string]int{"a": 1})
+		c.ValueIs([]int32{1}, []int{1})
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.ValueIs([]int32{1}, []int{2})
+		m.AssertFailed(t)
+	})
+}
@@ -0,0 +1,151 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// maxChannelElements is the maximum number of elements that are received from
+// a channel by `Each`, `AnyElement`, and `NoElement`.
+const maxChannelElements = 1000
+
+// ElementTester applies a test to every element of a slice, array, map, or
+// channel. For a map, the test is applied to each value, and the elements are
+// visited in order of their sorted keys.
+//
+// A channel is drained by receiving from it until it is empty or closed. At
+// most 1,000 elements are received. If the channel still has buffered
+// elements after that then the test fails rather than ignoring them. The
+// elements that were received are not put back.
+type ElementTester struct {
+	kind string
+	test any
+}
+
+// Each returns an `*ElementTester` which passes if every element matches the
+// expected value, which can be either a literal value or a Contester. Each
+// element which does not match is reported with its index or key. A value
+// with no elements passes.
+func (c *C) Each(expected any) *ElementTester {
+	return &ElementTester{kind: "Each", test: expected}
+}
+
+// AnyElement returns an `*ElementTester` which passes if at least one element
+// matches the expected value. If no element matches then the failures for
+// every element are reported.
+func (c *C) AnyElement(expected any) *ElementTester {
+	return &ElementTester{kind: "AnyElement", test: expected}
+}
+
+// NoElement returns an `*ElementTester` which passes if no element matches the
+// expected value. Each element which matches is reported with its index or
+// key.
+func (c *C) NoElement(expected any) *ElementTester {
+	return &ElementTester{kind: "NoElement", test: expected}
+}
+
+type element struct {
+	pathData string
+	value    any
+}
+
+func (et *ElementTester) Test(c *C, actual any) []*Result {
+	elements, res := c.elementsOf(actual, et.test, et.kind)
+	if res != nil {
+		return []*Result{res}
+	}
+
+	var failed []*Result
+	for _, e := range elements {
+		c.PushPath(Path{data: e.pathData})
+		results := c.is(e.value, et.test)
+
+		switch et.kind {
+		case "Each":
+			if !allPassed(results) {
+				failed = append(failed, results...)
+			}
+		case "AnyElement":
+			if allPassed(results) {
+				c.PopPath()
+				return []*Result{c.Pass(actual, et.test, et.kind)}
+			}
+			failed = append(failed, results...)
+		case "NoElement":
+			for _, r := range c.negate(e.value, et.test, results) {
+				if !r.pass {
+					failed = append(failed, r)
+				}
+			}
+		}
+		c.PopPath()
+	}
+
+	if et.kind == "AnyElement" {
+		if len(elements) == 0 {
+			return []*Result{c.FailStructure(fmt.Sprintf(
+				"The %s has no elements",
+				describeTypeOfActualValue(actual),
+			))}
+		}
+		return append(failed, c.FailStructure(fmt.Sprintf(
+			"None of the elements matched: %s tested",
+			countOf(len(elements), "element", "elements"),
+		)))
+	}
+
+	if len(failed) == 0 {
+		return []*Result{c.Pass(actual, et.test, et.kind)}
+	}
+	return failed
+}
+
+// elementsOf returns the elements of a slice, array, map, or channel, along
+// with the path data for each one. If the value is not one of these then this
+// returns a failed result instead.
+func (c *C) elementsOf(actual, expect any, op string) ([]element, *Result) {
+	v := reflect.ValueOf(actual)
+
+	var elements []element
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, element{fmt.Sprintf("[%d]", i), v.Index(i).Interface()})
+		}
+		return elements, nil
+	case reflect.Map:
+		for _, k := range sortedMapKeys(v) {
+			elements = append(elements, element{keyPathData(k), v.MapIndex(k).Interface()})
+		}
+		return elements, nil
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, c.FailUsage(fmt.Sprintf(
+				"Cannot receive from %s",
+				articleize(describeTypeOfActualValue(actual)),
+			))
+		}
+		for i := 0; i < maxChannelElements; i++ {
+			e, ok := v.TryRecv()
+			if !ok {
+				break
+			}
+			elements = append(elements, element{fmt.Sprintf("<-[%d]", i), e.Interface()})
+		}
+		if len(elements) == maxChannelElements && v.Len() > 0 {
+			return nil, c.FailStructure(fmt.Sprintf(
+				"The channel still had %s after receiving %d elements,"+
+					" which is the most that can be tested",
+				quantity(v.Len(), "buffered element", "buffered elements"),
+				maxChannelElements,
+			))
+		}
+		return elements, nil
+	}
+
+	return nil, c.FailType(actual, expect, op).WithDescription(fmt.Sprintf(
+		"Expected a slice, array, map, or channel but got %s",
+		articleize(describeTypeOfActualValue(actual)),
+	))
+}
@@ -0,0 +1,123 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElements(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+
+	newChan := func(vals ...int) chan int {
+		ch := make(chan int, len(vals))
+		for _, v := range vals {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+
+	tests := map[string]contesterTest{
+		"Each slice":                  {[]int{1, 2, 3}, c.Each(c.GreaterThan(0)), true},
+		"Each slice fails":            {[]int{1, -2, 3}, c.Each(c.GreaterThan(0)), false},
+		"Each literal":                {[]string{"a", "a"}, c.Each("a"), true},
+		"Each array":                  {[2]int{1, 2}, c.Each(c.LessThan(3)), true},
+		"Each map":                    {map[string]int{"a": 1, "b": 2}, c.Each(c.GreaterThan(0)), true},
+		"Each map fails":              {map[string]int{"a": 1, "b": -2}, c.Each(c.GreaterThan(0)), false},
+		"Each channel":                {newChan(1, 2), c.Each(c.GreaterThan(0)), true},
+		"Each channel fails":          {newChan(1, -2), c.Each(c.GreaterThan(0)), false},
+		"Each empty slice":            {[]int{}, c.Each(1), true},
+		"Each of int":                 {1, c.Each(1), false},
+		"Each of nil":                 {nil, c.Each(1), false},
+		"Each send-only channel":      {(chan<- int)(make(chan int)), c.Each(1), false},
+		"AnyElement slice":            {[]int{1, 2, 3}, c.AnyElement(2), true},
+		"AnyElement slice fails":      {[]int{1, 2, 3}, c.AnyElement(4), false},
+		"AnyElement map":              {map[string]int{"a": 1}, c.AnyElement(1), true},
+		"AnyElement channel":          {newChan(1, 2), c.AnyElement(2), true},
+		"AnyElement empty slice":      {[]int{}, c.AnyElement(1), false},
+		"NoElement slice":             {[]int{1, 2, 3}, c.NoElement(4), true},
+		"NoElement slice fails":       {[]int{1, 2, 3}, c.NoElement(c.GreaterThan(2)), false},
+		"NoElement empty slice":       {[]int{}, c.NoElement(1), true},
+		"NoElement map fails":         {map[string]int{"a": 1}, c.NoElement(1), false},
+		"Each with nested Contester":  {[]map[string]int{{"id": 1}, {"id": 2}}, c.Each(c.Map(c.Key("id").Is(c.GreaterThan(0)))), true},
+		"Each with nested failure":    {[]map[string]int{{"id": 1}, {"id": 0}}, c.Each(c.Map(c.Key("id").Is(c.GreaterThan(0)))), false},
+		"AnyElement with struct test": {[]account{{id: 1}, {id: 2}}, c.AnyElement(c.Object(c.Call("ID").Is(2))), true},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("channel with more than the limit", func(t *testing.T) {
+		for name, test := range map[string]Contester{
+			"Each":       c.Each(c.GreaterThan(0)),
+			"AnyElement": c.AnyElement(-1),
+			"NoElement":  c.NoElement(-1),
+		} {
+			ch := make(chan int, maxChannelElements+1)
+			for i := 0; i < maxChannelElements; i++ {
+				ch <- 1
+			}
+			ch <- -1
+			close(ch)
+
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(ch, test)
+			m.AssertFailed(t)
+			assert.Contains(t, m.output(), "still had 1 buffered element after", name)
+		}
+	})
+
+	t.Run("AnyElement description", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		for n, desc := range map[int]string{
+			1: "None of the elements matched: 1 element was tested",
+			2: "None of the elements matched: 2 elements were tested",
+		} {
+			c.ResetState()
+			res := c.is(make([]int, n), c.AnyElement(1))
+			if assert.Len(t, res, n+1) {
+				assert.Equal(t, desc, res[n].Description())
+			}
+		}
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+
+		c.ResetState()
+		c.PushPath(Path{data: "[]int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is([]int{1, -2, 3, -4}, c.Each(c.GreaterThan(0)))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"[]int", "[1]"}},
+				{pass: false, dataPath: []string{"[]int", "[3]"}},
+			},
+			"Each",
+		)
+
+		c.ResetState()
+		c.PushPath(Path{data: "map[string]int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is(map[string]int{"b": 2, "a": 1}, c.NoElement(c.GreaterThan(0)))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"map[string]int", `["a"]`}},
+				{pass: false, dataPath: []string{"map[string]int", `["b"]`}},
+			},
+			"NoElement",
+		)
+
+		c.ResetState()
+		c.PushPath(Path{data: "chan int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is(newChan(1, -2), c.Each(c.GreaterThan(0)))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"chan int", "<-[1]"}},
+			},
+			"Each channel",
+		)
+	})
+}
@@ -1,6 +1,9 @@
 package contesta
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+)
 
 var vowelRE = regexp.MustCompile(`^[aeiou]`)
 
@@ -10,3 +13,21 @@ func articleize(noun string) string {
 	}
 	return "a " + noun
 }
+
+// countOf returns the count followed by the singular or plural noun, along
+// with the matching form of "to be", like "1 field was" or "2 fields were".
+func countOf(n int, singular, plural string) string {
+	if n == 1 {
+		return quantity(n, singular, plural) + " was"
+	}
+	return quantity(n, singular, plural) + " were"
+}
+
+// quantity returns the count followed by the singular or plural noun, like "1
+// element" or "2 elements".
+func quantity(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
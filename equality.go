@@ -1,7 +1,6 @@
 package contesta
 
 import (
-	"bytes"
 	"fmt"
 	"math"
 	"reflect"
@@ -13,50 +12,28 @@ type ExactEqualityTester struct {
 	expect any
 }
 
-func (eet *ExactEqualityTester) Test(c *C, actual any) []*result {
-	res := &result{
-		actual: newValue(actual),
-		expect: newValue(eet.expect),
-		op:     "==",
-		paths:  c.Paths(),
-	}
-
+func (eet *ExactEqualityTester) Test(c *C, actual any) []*Result {
 	actualType := reflect.TypeOf(actual)
 	expectType := reflect.TypeOf(eet.expect)
 	if actualType == expectType {
-		res.pass = exactCompare(actual, eet.expect)
-		if !res.pass {
-			res.where = inValue
+		if diffs := c.diff(actual, eet.expect, "=="); len(diffs) > 0 {
+			return diffs
 		}
-	} else {
-		res.pass = nilValuesAreEqual(actual, eet.expect)
-		if res.pass {
-			res.where = inType
-		}
-	}
-
-	return []*result{res}
-}
-
-func exactCompare(actual, expect interface{}) bool {
-	if actual == nil || expect == nil {
-		// Two nils are only equal if they're also the same type.
-		return actual == expect
 	}
 
-	exp, ok := expect.([]byte)
-	if !ok {
-		// Need to replace this with something that traverses a data structure
-		// recording our path as we go.
-		return reflect.DeepEqual(actual, expect)
+	res := &Result{
+		actual: newValue(actual),
+		expect: newValue(eet.expect),
+		op:     "==",
+		paths:  c.Paths(),
+		pass:   actualType == expectType || nilValuesAreEqual(actual, eet.expect),
 	}
-
-	act, ok := actual.([]byte)
-	if !ok {
-		return false
+	if !res.pass {
+		res.where = inType
+		res.mismatch = true
 	}
 
-	return bytes.Equal(act, exp)
+	return []*Result{res}
 }
 
 func nilValuesAreEqual(actual, expect interface{}) bool {
@@ -79,15 +56,15 @@ func nilValuesAreEqual(actual, expect interface{}) bool {
 	return false
 }
 
+// isNilable returns true for kinds where `reflect.Value.IsNil` can be called.
+// Arrays and structs are never nil, and calling `IsNil` on them panics.
 func isNilable(kind reflect.Kind) bool {
-	return kind == reflect.Array ||
-		kind == reflect.Chan ||
+	return kind == reflect.Chan ||
 		kind == reflect.Func ||
 		kind == reflect.Interface ||
 		kind == reflect.Map ||
 		kind == reflect.Ptr ||
 		kind == reflect.Slice ||
-		kind == reflect.Struct ||
 		kind == reflect.UnsafePointer
 }
 
@@ -102,54 +79,23 @@ func (c *C) ValueEqual(expect interface{}) ValueEqualityTester {
 	return ValueEqualityTester{expect}
 }
 
-// Compare compares the value in d.Actual() to the expected value passed to
-// ValueEqual().
-func (vec ValueEqualityTester) Test(c *C, actual any) []*result {
+// Test compares the actual value to the expected value passed to
+// ValueEqual(). Slices, arrays, maps, pointers, and structs are compared
+// element by element, so `[]int32{1}` is equal to `[]int{1}`, and each element
+// which differs is reported with its own path.
+func (vec ValueEqualityTester) Test(c *C, actual any) []*Result {
 	expect := vec.expect
-	res := &result{
-		actual: newValue(actual),
-		expect: newValue(expect),
-		op:     "== (value)",
-		paths:  c.Paths(),
-	}
-
-	actualType := reflect.TypeOf(actual)
-	expectType := reflect.TypeOf(expect)
-	if actualType == expectType {
-		res.pass = exactCompare(actual, expect)
-		if !res.pass {
-			res.where = inValue
-		}
-		return []*result{res}
-	}
+	op := "== (value)"
 
 	if nilValuesAreEqual(actual, expect) || actual == nil && expect == nil {
-		res.pass = true
-		return []*result{res}
-
-	}
-
-	if !actualType.ConvertibleTo(expectType) {
-		res.pass = false
-		res.where = inType
-		res.description = cannotConvertMessage(actualType, expectType)
-		return []*result{res}
-	}
-
-	actualVal, expectVal, desc := maybeConvertValues(actual, expect, actualType, expectType)
-	if desc != "" {
-		res.pass = false
-		res.where = inType
-		res.description = desc
-		return []*result{res}
+		return []*Result{c.Pass(actual, expect, op)}
 	}
 
-	res.pass = actualVal.Interface() == expectVal.Interface()
-	if !res.pass {
-		res.where = inValue
+	if diffs := c.valueDiff(actual, expect, op); len(diffs) > 0 {
+		return diffs
 	}
 
-	return []*result{res}
+	return []*Result{c.Pass(actual, expect, op)}
 }
 
 func cannotConvertMessage(actualType, expectType reflect.Type) string {
@@ -160,29 +106,6 @@ func cannotConvertMessage(actualType, expectType reflect.Type) string {
 	)
 }
 
-func maybeConvertValues(actual, expect interface{}, actualType, expectType reflect.Type) (
-	reflect.Value, reflect.Value, string,
-) {
-	actualVal := reflect.ValueOf(actual)
-	expectVal := reflect.ValueOf(expect)
-
-	if actualNumeric := isNumeric(actualVal); actualNumeric != nil {
-		if expectNumeric := isNumeric(expectVal); expectNumeric != nil {
-			return safelyConvertNumberTypes(actualVal, expectVal, actualNumeric, expectNumeric)
-		}
-
-		// It shouldn't really be possible to get here since we checked the
-		// results of ConvertibleTo for the 2 types earlier.
-		return actualVal, expectVal, cannotConvertMessage(actualType, expectType)
-	}
-
-	// We should only end up here when we have two non-numeric types that are
-	// identical in form but not name. This can happen when one type is an
-	// alias for the other (`type StringLike string`) or when two structs have
-	// identical fields but different type names.
-	return actualVal, expectVal.Convert(actualType), ""
-}
-
 type numericInfo struct {
 	baseType string
 	bits     int
@@ -280,6 +203,16 @@ func safelyConvertNumberTypes(
 	)
 }
 
+// convertNumber converts a number to another numeric type. The second return
+// value is false if the conversion would change the value, for example when
+// converting 1.5 to an int or -1 to a uint. Values are compared using the same
+// rules as `c.ValueIs`.
+func convertNumber(v reflect.Value, ty reflect.Type) (reflect.Value, bool) {
+	converted := v.Convert(ty)
+	cmp, desc := compareNumbers(v, converted)
+	return converted, desc == "" && cmp == 0
+}
+
 func intUintConversion(
 	int, uint reflect.Value,
 	intInfo, uintInfo *numericInfo,
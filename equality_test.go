@@ -0,0 +1,50 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilValuesAreEqual(t *testing.T) {
+	var nilPtr *int
+	var nilSlice []int
+
+	tests := map[string]struct {
+		actual any
+		expect any
+		equal  bool
+	}{
+		"untyped nils":              {nil, nil, true},
+		"untyped and typed nil":     {nil, nilPtr, true},
+		"typed and untyped nil":     {nilSlice, nil, true},
+		"non-nil pointer":           {new(int), nil, false},
+		"struct and nil":            {struct{}{}, nil, false},
+		"nil and struct":            {nil, valueError{}, false},
+		"array and nil":             {[2]int{}, nil, false},
+		"nil and zero-length array": {nil, [0]int{}, false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				assert.Equal(t, test.equal, nilValuesAreEqual(test.actual, test.expect))
+			})
+		})
+	}
+
+	t.Run("Is with a struct and nil", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.NotPanics(t, func() { c.Is(struct{}{}, nil) })
+		m.AssertFailed(t)
+	})
+
+	t.Run("NoError with a struct error", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.NotPanics(t, func() { c.Is(valueError{}, c.NoError()) })
+		m.AssertFailed(t)
+	})
+}
@@ -0,0 +1,194 @@
+package contesta
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ErrorTester tests an error value. When a test fails, the GOT column shows
+// the error's entire `Unwrap` chain, including every branch of errors created
+// with `errors.Join`.
+type ErrorTester struct {
+	expect any
+	op     string
+	check  func(err error) bool
+	err    error
+}
+
+// NoError returns an `*ErrorTester` which passes if the actual value is a nil
+// error. A typed nil, such as a nil `*MyError`, is treated as nil.
+func (c *C) NoError() *ErrorTester {
+	return &ErrorTester{
+		expect: nil,
+		op:     "==",
+		check:  func(err error) bool { return err == nil },
+	}
+}
+
+// ErrorIs returns an `*ErrorTester` which passes if `errors.Is(actual,
+// target)` returns true.
+func (c *C) ErrorIs(target error) *ErrorTester {
+	return &ErrorTester{
+		expect: target,
+		op:     "errors.Is",
+		check:  func(err error) bool { return errors.Is(err, target) },
+	}
+}
+
+// ErrorMatches returns an `*ErrorTester` which passes if the actual value is a
+// non-nil error whose message matches the given regular expression. The `re`
+// argument can be either a `string` or a `*regexp.Regexp`.
+func (c *C) ErrorMatches(re any) *ErrorTester {
+	r, err := toRegexp(re)
+	if err != nil {
+		return &ErrorTester{expect: re, op: "=~", err: err}
+	}
+	return &ErrorTester{
+		expect: r,
+		op:     "=~",
+		check:  func(err error) bool { return err != nil && r.MatchString(err.Error()) },
+	}
+}
+
+func (et *ErrorTester) Test(c *C, actual any) []*Result {
+	if et.err != nil {
+		return []*Result{c.FailUsage(fmt.Sprintf("Invalid pattern: %s", et.err))}
+	}
+
+	err, res := c.errorOf(actual, et.expect, et.op)
+	if res != nil {
+		return []*Result{res}
+	}
+
+	if et.check(err) {
+		return []*Result{c.Pass(actual, et.expect, et.op)}
+	}
+	return []*Result{c.failError(err, et.expect, et.op)}
+}
+
+// ErrorAsTester tests that an error's chain contains an error which can be
+// assigned to a target, using `errors.As`. It can optionally test the value
+// that was found.
+type ErrorAsTester struct {
+	target any
+	test   any
+}
+
+// ErrorAs returns an `*ErrorAsTester` which passes if `errors.As(actual,
+// target)` returns true. The target must be a non-nil pointer to either an
+// interface or a type which implements `error`. Call `Is` on the returned
+// tester to test the value that `errors.As` found.
+func (c *C) ErrorAs(target any) *ErrorAsTester {
+	return &ErrorAsTester{target: target}
+}
+
+// Is adds a test for the value that `errors.As` found. The expected value can
+// be a literal value or a Contester.
+func (eat *ErrorAsTester) Is(expected any) *ErrorAsTester {
+	eat.test = expected
+	return eat
+}
+
+func (eat *ErrorAsTester) Test(c *C, actual any) []*Result {
+	tv := reflect.ValueOf(eat.target)
+	if !tv.IsValid() || tv.Kind() != reflect.Ptr || tv.IsNil() {
+		return []*Result{c.FailUsage("The target passed to ErrorAs must be a non-nil pointer")}
+	}
+
+	targetType := tv.Type().Elem()
+	if targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
+		return []*Result{c.FailUsage(fmt.Sprintf(
+			"The target passed to ErrorAs must point to an interface or an error type, not %s",
+			articleize(describeType(targetType)),
+		))}
+	}
+
+	expect := describeType(targetType)
+	err, res := c.errorOf(actual, expect, "errors.As")
+	if res != nil {
+		return []*Result{res}
+	}
+
+	if !errors.As(err, eat.target) {
+		return []*Result{c.failError(err, expect, "errors.As")}
+	}
+
+	if eat.test == nil {
+		return []*Result{c.Pass(actual, expect, "errors.As")}
+	}
+
+	c.PushPath(Path{data: fmt.Sprintf(".(%s)", expect)})
+	defer c.PopPath()
+
+	return c.is(tv.Elem().Interface(), eat.test)
+}
+
+// errorOf returns the actual value as an error. A typed nil is returned as an
+// untyped nil. If the actual value is not an error then this returns a failed
+// result instead.
+func (c *C) errorOf(actual, expect any, op string) (error, *Result) {
+	if nilValuesAreEqual(actual, nil) {
+		return nil, nil
+	}
+
+	err, ok := actual.(error)
+	if !ok {
+		return nil, c.FailType(actual, expect, op).WithDescription(fmt.Sprintf(
+			"Expected an error but got %s",
+			articleize(describeTypeOfActualValue(actual)),
+		))
+	}
+
+	return err, nil
+}
+
+// failError returns a failed result which shows the error's entire chain as
+// the actual value.
+func (c *C) failError(err error, expect any, op string) *Result {
+	if err == nil {
+		return c.FailValue(nil, expect, op)
+	}
+
+	res := c.FailValue(errorChain(err), expect, op)
+	res.actual.desc = describeTypeOfActualValue(err)
+	return res
+}
+
+// errorChain returns a description of the error and every error in its
+// `Unwrap` chain, one per line, indented to show how they are nested.
+func errorChain(err error) string {
+	var lines []string
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		// Messages from joined errors contain newlines, so we indent every
+		// line of the message.
+		indent := strings.Repeat("  ", depth)
+		lines = append(lines, fmt.Sprintf(
+			"%s%s: %s",
+			indent,
+			describeTypeOfActualValue(err),
+			strings.ReplaceAll(err.Error(), "\n", "\n"+indent+"  "),
+		))
+
+		// nolint: errorlint
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				walk(next, depth+1)
+			}
+		case interface{ Unwrap() []error }:
+			for _, next := range u.Unwrap() {
+				if next != nil {
+					walk(next, depth+1)
+				}
+			}
+		}
+	}
+	walk(err, 0)
+
+	return strings.Join(lines, "\n")
+}
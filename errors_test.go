@@ -0,0 +1,160 @@
+package contesta
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type myError struct {
+	code int
+}
+
+func (e *myError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestErrorTesters(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	wrapped := fmt.Errorf("read config: %w", fs.ErrNotExist)
+	tests := map[string]contesterTest{
+		"NoError with nil": {
+			actual: nil,
+			expect: c.NoError(),
+			pass:   true,
+		},
+		"NoError with typed nil": {
+			actual: (*myError)(nil),
+			expect: c.NoError(),
+			pass:   true,
+		},
+		"NoError with error": {
+			actual: wrapped,
+			expect: c.NoError(),
+			pass:   false,
+		},
+		"NoError with non-error": {
+			actual: 42,
+			expect: c.NoError(),
+			pass:   false,
+		},
+		"ErrorIs with wrapped error": {
+			actual: wrapped,
+			expect: c.ErrorIs(fs.ErrNotExist),
+			pass:   true,
+		},
+		"ErrorIs with joined error": {
+			actual: errors.Join(errors.New("x"), wrapped),
+			expect: c.ErrorIs(fs.ErrNotExist),
+			pass:   true,
+		},
+		"ErrorIs with different error": {
+			actual: wrapped,
+			expect: c.ErrorIs(fs.ErrExist),
+			pass:   false,
+		},
+		"ErrorIs with nil": {
+			actual: nil,
+			expect: c.ErrorIs(fs.ErrExist),
+			pass:   false,
+		},
+		"ErrorMatches": {
+			actual: wrapped,
+			expect: c.ErrorMatches(`^read config: `),
+			pass:   true,
+		},
+		"ErrorMatches with nil": {
+			actual: nil,
+			expect: c.ErrorMatches(`.`),
+			pass:   false,
+		},
+		"ErrorAs": {
+			actual: fmt.Errorf("wrapped: %w", &myError{code: 42}),
+			expect: c.ErrorAs(new(*myError)),
+			pass:   true,
+		},
+		"ErrorAs with nested test": {
+			actual: fmt.Errorf("wrapped: %w", &myError{code: 42}),
+			expect: c.ErrorAs(new(*myError)).Is(c.Struct(c.Field("code").Is(43)).AllowUnexported()),
+			pass:   false,
+		},
+		"ErrorAs with no match": {
+			actual: wrapped,
+			expect: c.ErrorAs(new(*myError)),
+			pass:   false,
+		},
+		"ErrorAs with bad target": {
+			actual: wrapped,
+			expect: c.ErrorAs(42),
+			pass:   false,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("Unwrap chain in output", func(t *testing.T) {
+		lines := plainOutput(t, wrapped, func(c *C) Contester { return c.ErrorIs(fs.ErrPermission) })
+		if assert.Len(t, lines, 2) {
+			assert.Contains(
+				t,
+				lines[0],
+				"got *wrapError *wrapError: read config: file does not exist",
+			)
+			assert.True(
+				t,
+				strings.HasPrefix(
+					lines[1],
+					"  *errorString: file does not exist expected errors.Is *errorString permission denied",
+				),
+				"second line: %s", lines[1],
+			)
+		}
+	})
+
+	t.Run("Unwrap chain in result", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(wrapped, c.ErrorIs(fs.ErrPermission))
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, "errors.Is", res[0].Op())
+			actual, _ := res[0].Actual()
+			assert.Equal(
+				t,
+				"*wrapError: read config: file does not exist\n  *errorString: file does not exist",
+				actual,
+			)
+			assert.Equal(t, "*wrapError", res[0].actual.description())
+			expect, _ := res[0].Expect()
+			assert.Equal(t, fs.ErrPermission, expect)
+		}
+	})
+}
+
+func Test_errorChain(t *testing.T) {
+	err := fmt.Errorf("read config: %w", errors.Join(fs.ErrNotExist, &myError{code: 1}))
+	assert.Equal(
+		t,
+		"*wrapError: read config: file does not exist\n  code 1\n"+
+			"  *joinError: file does not exist\n    code 1\n"+
+			"    *errorString: file does not exist\n"+
+			"    *myError: code 1",
+		errorChain(err),
+	)
+}
+
+type valueError struct{}
+
+func (valueError) Error() string {
+	return "value error"
+}
+
+func TestNoErrorWithValueReceiver(t *testing.T) {
+	m := newMockT()
+	c := NewWithOutput(m, m)
+	c.Is(valueError{}, c.NoError())
+	m.AssertFailed(t)
+}
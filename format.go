@@ -0,0 +1,306 @@
+package contesta
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Formatter turns the results of an assertion into output. A `*C` passes the
+// results of every assertion to its formatter and writes whatever the
+// formatter returns.
+//
+// The formatter for a `*C` is picked using the `CONTESTA_FORMAT` environment
+// variable, which can be "table" (the default), "plain", "json", or "tap". You
+// can also call `c.SetFormatter`.
+type Formatter interface {
+	// Format returns the output for a single assertion. The results include
+	// both passes and failures.
+	Format(name string, results []*Result, s Scheme) string
+	// Done returns any output that must follow the last assertion, such as a
+	// TAP plan. It is called by `c.Done`.
+	Done() string
+}
+
+// SetFormatter sets the formatter used for the output of this `*C`.
+func (c *C) SetFormatter(f Formatter) {
+	c.formatter = f
+}
+
+// Done tells the `*C` that there are no more assertions, so that its
+// formatter can write any final output. This is only needed for formats which
+// have a trailer, like the plan line for a TAP formatter set with
+// `c.SetFormatter`. You can call it with `defer` right after creating the
+// `*C`.
+//
+// This does nothing for a `*C` using the TAP stream that is shared when
+// `CONTESTA_FORMAT` is "tap". Call the package-level `Done` for that instead.
+func (c *C) Done() {
+	if c.formatter == Formatter(sharedTAPFormatter) {
+		return
+	}
+	if out := c.formatter.Done(); out != "" {
+		c.output.WriteString(out)
+	}
+}
+
+// sharedTAPFormatter is used by every `*C` created while `CONTESTA_FORMAT` is
+// "tap", so that the assertions from every test and subtest are numbered in a
+// single TAP stream with a single plan.
+var sharedTAPFormatter = NewTAPFormatter()
+
+// Done writes the plan for the TAP stream shared by every `*C` created while
+// `CONTESTA_FORMAT` is "tap" to `os.Stdout`. Call it once after all of the
+// tests have run, for example from `TestMain`:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		contesta.Done()
+//		os.Exit(code)
+//	}
+//
+// It does nothing for any other format.
+func Done() {
+	if strings.ToLower(os.Getenv("CONTESTA_FORMAT")) == "tap" {
+		os.Stdout.WriteString(sharedTAPFormatter.Done())
+	}
+}
+
+func formatterFromEnv() Formatter {
+	switch strings.ToLower(os.Getenv("CONTESTA_FORMAT")) {
+	case "plain":
+		return NewPlainFormatter()
+	case "json":
+		return NewJSONFormatter()
+	case "tap":
+		return sharedTAPFormatter
+	}
+	return NewTableFormatter()
+}
+
+// TableFormatter describes every result with a table showing the path, the
+// values that were compared, and the caller. This is the default.
+type TableFormatter struct{}
+
+// NewTableFormatter returns a new `*TableFormatter`.
+func NewTableFormatter() *TableFormatter {
+	return &TableFormatter{}
+}
+
+func (*TableFormatter) Format(name string, results []*Result, s Scheme) string {
+	var out strings.Builder
+	for _, r := range results {
+		out.WriteString(r.describe(name, s))
+	}
+	return out.String()
+}
+
+func (*TableFormatter) Done() string {
+	return ""
+}
+
+// PlainFormatter writes a single "ok" line for an assertion which passes and
+// one line for each failure in an assertion which fails.
+type PlainFormatter struct{}
+
+// NewPlainFormatter returns a new `*PlainFormatter`.
+func NewPlainFormatter() *PlainFormatter {
+	return &PlainFormatter{}
+}
+
+func (*PlainFormatter) Format(name string, results []*Result, s Scheme) string {
+	if allPassed(results) {
+		return s.Correct("ok") + " " + name + "\n"
+	}
+
+	var out strings.Builder
+	for _, r := range results {
+		if r.pass {
+			continue
+		}
+		out.WriteString(s.Incorrect("not ok") + " " + name + ": " + r.plainFailure() + "\n")
+	}
+	return out.String()
+}
+
+func (*PlainFormatter) Done() string {
+	return ""
+}
+
+// JSONFormatter writes one JSON object per line for each assertion.
+type JSONFormatter struct{}
+
+// NewJSONFormatter returns a new `*JSONFormatter`.
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+type jsonAssertion struct {
+	Name    string       `json:"name"`
+	Pass    bool         `json:"pass"`
+	Results []jsonResult `json:"results"`
+}
+
+type jsonResult struct {
+	Pass        bool       `json:"pass"`
+	Path        []string   `json:"path,omitempty"`
+	Got         *jsonValue `json:"got,omitempty"`
+	Op          string     `json:"op,omitempty"`
+	Expect      *jsonValue `json:"expect,omitempty"`
+	Failure     string     `json:"failure,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Caller      string     `json:"caller,omitempty"`
+}
+
+type jsonValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (*JSONFormatter) Format(name string, results []*Result, _ Scheme) string {
+	a := jsonAssertion{Name: name, Pass: allPassed(results)}
+	for _, r := range results {
+		jr := jsonResult{
+			Pass:        r.pass,
+			Path:        r.pathData(),
+			Got:         r.actual.jsonValue(),
+			Op:          r.op,
+			Expect:      r.expect.jsonValue(),
+			Description: r.description,
+			Caller:      r.calledAt(),
+		}
+		if !r.pass {
+			jr.Failure = r.where.String()
+		}
+		a.Results = append(a.Results, jr)
+	}
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		// This should be impossible since every field is a string or bool.
+		panic(fmt.Sprintf("Could not marshal results to JSON: %s", err))
+	}
+	return string(b) + "\n"
+}
+
+func (*JSONFormatter) Done() string {
+	return ""
+}
+
+func (v *value) jsonValue() *jsonValue {
+	if v == nil {
+		return nil
+	}
+	return &jsonValue{Type: v.typeName(), Value: fmt.Sprintf("%v", v.value)}
+}
+
+// TAPFormatter writes output in the Test Anything Protocol format. Each
+// assertion is numbered, and the failures for an assertion are written as
+// diagnostic lines.
+//
+// A `TAPFormatter` numbers every assertion it formats, so the `*C` values
+// which share one produce a single stream. When `CONTESTA_FORMAT` is "tap",
+// every `*C` shares one formatter, and you should call the package-level
+// `Done` once to write the plan. If you give a `*C` its own formatter with
+// `c.SetFormatter(contesta.NewTAPFormatter())`, call `c.Done` after its last
+// assertion instead.
+type TAPFormatter struct {
+	mu    sync.Mutex
+	count int
+}
+
+// NewTAPFormatter returns a new `*TAPFormatter`.
+func NewTAPFormatter() *TAPFormatter {
+	return &TAPFormatter{}
+}
+
+func (tf *TAPFormatter) Format(name string, results []*Result, _ Scheme) string {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	tf.count++
+	if allPassed(results) {
+		return fmt.Sprintf("ok %d - %s\n", tf.count, name)
+	}
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("not ok %d - %s\n", tf.count, name))
+	for _, r := range results {
+		if r.pass {
+			continue
+		}
+		for _, line := range strings.Split(r.plainFailure(), "\n") {
+			out.WriteString("# " + line + "\n")
+		}
+	}
+	return out.String()
+}
+
+func (tf *TAPFormatter) Done() string {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	return fmt.Sprintf("1..%d\n", tf.count)
+}
+
+// plainFailure returns a description of a failed result that fits on a single
+// line, unless the description itself has more than one line.
+func (r *Result) plainFailure() string {
+	var parts []string
+	if path := r.pathData(); len(path) > 0 {
+		parts = append(parts, strings.Join(path, " ")+":")
+	}
+	if r.actual != nil {
+		parts = append(parts, fmt.Sprintf("got %s %v", r.actual.typeName(), r.actual.value))
+	}
+	if r.expect != nil {
+		expect := fmt.Sprintf("%s %v", r.expect.typeName(), r.expect.value)
+		if r.op != "" {
+			expect = r.op + " " + expect
+		}
+		parts = append(parts, "expected "+expect)
+	}
+	if r.description != "" {
+		parts = append(parts, "- "+strings.ReplaceAll(r.description, "\n", "\n  "))
+	}
+	if at := r.calledAt(); at != "" {
+		parts = append(parts, "("+at+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// pathData returns the data for each element of the result's path.
+func (r *Result) pathData() []string {
+	var data []string
+	for _, p := range r.paths {
+		data = append(data, p.data)
+	}
+	return data
+}
+
+// calledAt returns the caller for the innermost element of the result's path
+// which has one.
+func (r *Result) calledAt() string {
+	for i := len(r.paths) - 1; i >= 0; i-- {
+		if at := r.paths[i].CalledAt(); at != "" {
+			return at
+		}
+	}
+	return ""
+}
+
+func (f failure) String() string {
+	switch f {
+	case inType:
+		return "type"
+	case inValue:
+		return "value"
+	case inDataStructure:
+		return "structure"
+	case inUsage:
+		return "usage"
+	}
+	return ""
+}
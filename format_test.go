@@ -0,0 +1,144 @@
+package contesta
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatters(t *testing.T) {
+	t.Setenv("CONTESTA_COLOR", "never")
+
+	t.Run("plain", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.SetFormatter(NewPlainFormatter())
+		c.Is(map[string]int{"a": 1, "b": 2}, c.Map(c.Key("a").Is(1), c.Key("b").Is(2)), "passes")
+		c.Is([]int{1, 2}, []int{1, 3}, "fails")
+
+		lines := strings.Split(strings.TrimSuffix(m.output(), "\n"), "\n")
+		if assert.Len(t, lines, 2) {
+			assert.Equal(t, "ok passes", lines[0])
+			assert.True(
+				t,
+				strings.HasPrefix(lines[1], "not ok fails: []int [1]: got int 2 expected == int 3 ("),
+				"failure line: %s", lines[1],
+			)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.SetFormatter(NewJSONFormatter())
+		c.Is(42, 42, "passes")
+		c.Is([]int{1, 2}, []int{1, 3}, "fails")
+
+		lines := strings.Split(strings.TrimSuffix(m.output(), "\n"), "\n")
+		if !assert.Len(t, lines, 2) {
+			return
+		}
+
+		var pass jsonAssertion
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &pass))
+		assert.Equal(t, "passes", pass.Name)
+		assert.True(t, pass.Pass)
+
+		var fail jsonAssertion
+		assert.NoError(t, json.Unmarshal([]byte(lines[1]), &fail))
+		assert.Equal(t, "fails", fail.Name)
+		assert.False(t, fail.Pass)
+		if assert.Len(t, fail.Results, 1) {
+			r := fail.Results[0]
+			assert.Equal(t, []string{"[]int", "[1]"}, r.Path)
+			assert.Equal(t, &jsonValue{Type: "int", Value: "2"}, r.Got)
+			assert.Equal(t, &jsonValue{Type: "int", Value: "3"}, r.Expect)
+			assert.Equal(t, "==", r.Op)
+			assert.Equal(t, "value", r.Failure)
+			assert.Contains(t, r.Caller, "called contesta.(*C).Is")
+		}
+	})
+
+	t.Run("tap", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.SetFormatter(NewTAPFormatter())
+		c.Is(42, 42, "passes")
+		c.Require().Is(42, 43, "fails")
+		c.Done()
+
+		lines := strings.Split(strings.TrimSuffix(m.output(), "\n"), "\n")
+		if assert.Len(t, lines, 4) {
+			assert.Equal(t, "ok 1 - passes", lines[0])
+			assert.Equal(t, "not ok 2 - fails", lines[1])
+			assert.True(t, strings.HasPrefix(lines[2], "# int: got int 42 expected == int 43"), lines[2])
+			assert.Equal(t, "1..2", lines[3])
+		}
+	})
+
+	t.Run("nil values", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.SetFormatter(NewPlainFormatter())
+		c.Isnt(nil, nil, "plain")
+		assert.True(
+			t,
+			strings.HasPrefix(m.output(), "not ok plain: nil: got nil <nil> expected != nil <nil> ("),
+			"plain output: %s", m.output(),
+		)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.SetFormatter(NewTAPFormatter())
+		c.Is(any(nil), c.NotNil(), "tap")
+		lines := strings.Split(strings.TrimSuffix(m.output(), "\n"), "\n")
+		if assert.Len(t, lines, 2) {
+			assert.True(t, strings.HasPrefix(lines[1], "# nil: got nil <nil> expected "), lines[1])
+			assert.NotContains(t, lines[1], "nil <nil> <nil>")
+		}
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.SetFormatter(NewJSONFormatter())
+		c.Isnt(nil, nil, "json")
+		var fail jsonAssertion
+		assert.NoError(t, json.Unmarshal([]byte(m.output()), &fail))
+		if assert.Len(t, fail.Results, 1) {
+			assert.Equal(t, &jsonValue{Type: "nil", Value: "<nil>"}, fail.Results[0].Got)
+			assert.Equal(t, &jsonValue{Type: "nil", Value: "<nil>"}, fail.Results[0].Expect)
+		}
+	})
+
+	t.Run("shared tap stream", func(t *testing.T) {
+		t.Setenv("CONTESTA_FORMAT", "tap")
+		sharedTAPFormatter.count = 0
+
+		m := newMockT()
+		for _, name := range []string{"first", "second"} {
+			// Each subtest gets its own *C, but the assertions are numbered in
+			// a single stream.
+			c := NewWithOutput(m, m)
+			c.Is(42, 42, name)
+			c.Done()
+		}
+
+		assert.Equal(t, "ok 1 - first\nok 2 - second\n", m.output())
+		assert.Equal(t, "1..2\n", sharedTAPFormatter.Done())
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		for format, expect := range map[string]Formatter{
+			"":      NewTableFormatter(),
+			"table": NewTableFormatter(),
+			"plain": NewPlainFormatter(),
+			"JSON":  NewJSONFormatter(),
+			"tap":   sharedTAPFormatter,
+			"bogus": NewTableFormatter(),
+		} {
+			t.Setenv("CONTESTA_FORMAT", format)
+			assert.IsType(t, expect, NewWithOutput(newMockT(), newMockT()).formatter, format)
+		}
+	})
+}
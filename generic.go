@@ -0,0 +1,252 @@
+package contesta
+
+import (
+	"reflect"
+)
+
+// Equal tests that two values of the same type are exactly equal. It works
+// like `c.Is`, except that the compiler checks that the actual and expected
+// values have the same type, so a mismatch like comparing an `int64` to an
+// `int` is a compile error rather than a test failure.
+//
+// The final arguments follow the same rules as `c.Is`.
+func Equal[T any](c *C, got, want T, args ...any) bool {
+	c.t.Helper()
+	c.ResetState()
+
+	actualType := reflect.TypeOf(got)
+	c.PushPath(c.NewPath(describeType(actualType), 0, "contesta.Equal"))
+	defer c.PopPath()
+
+	eet := &ExactEqualityTester{want}
+	return c.processResults(eet.Test(c, got), "Equal", args)
+}
+
+// IncompleteKeyOfTest is returned by `KeyOf`. Call its `Is` or `Matches`
+// method to turn it into a `MapKeyTestOf`.
+type IncompleteKeyOfTest[K comparable, V any] struct {
+	key K
+	c   *C
+}
+
+// MapKeyTestOf is a `MapKeyTest` for a `map[K]V`. It is created by `KeyOf`.
+// It can be passed to `c.Map`, but passing it to `MapOf` means that the
+// compiler checks that its key and value types match the map's types.
+type MapKeyTestOf[K comparable, V any] struct {
+	test MapKeyTest
+}
+
+// KeyOf is a typed version of `c.Key` for use with a `map[K]V`. Because the
+// value type cannot be inferred from the key, both type parameters must be
+// given. Use it with `MapOf` so that the type of the map is checked too:
+//
+//	contesta.MapOf[string, int](c, contesta.KeyOf[string, int](c, "foo").Is(42)).Is(m)
+func KeyOf[K comparable, V any](c *C, key K) IncompleteKeyOfTest[K, V] {
+	return IncompleteKeyOfTest[K, V]{key, c}
+}
+
+// Is returns a `MapKeyTestOf` which checks that the key exists and that its
+// value is exactly equal to the expected value.
+func (ikt IncompleteKeyOfTest[K, V]) Is(expected V) MapKeyTestOf[K, V] {
+	return MapKeyTestOf[K, V]{MapKeyTest{
+		key:    ikt.key,
+		check:  keyIs,
+		test:   &ExactEqualityTester{expected},
+		caller: ikt.c.Caller(),
+		callee: "contesta.IncompleteKeyOfTest.Is",
+	}}
+}
+
+// Matches returns a `MapKeyTestOf` which checks that the key exists and that
+// its value matches the Contester.
+func (ikt IncompleteKeyOfTest[K, V]) Matches(test Contester) MapKeyTestOf[K, V] {
+	return MapKeyTestOf[K, V]{MapKeyTest{
+		key:    ikt.key,
+		check:  keyIs,
+		test:   test,
+		caller: ikt.c.Caller(),
+		callee: "contesta.IncompleteKeyOfTest.Matches",
+	}}
+}
+
+func (MapKeyTestOf[K, V]) isMapTest() {}
+
+func (mkt MapKeyTestOf[K, V]) Test(c *C, value reflect.Value) []*Result {
+	return mkt.test.Test(c, value)
+}
+
+// IncompleteSliceTestOf is returned by `NextOf` and `IdxOf`. Call its `Is` or
+// `Matches` method to turn it into a `SliceElementTestOf`.
+type IncompleteSliceTestOf[T any] struct {
+	index  int
+	isNext bool
+	c      *C
+}
+
+// SliceElementTestOf is a `SliceElementTest` for a `[]T` or an array of `T`.
+// It is created by `NextOf` or `IdxOf`. It can be passed to `c.Slice`, but
+// passing it to `SliceOf` means that the compiler checks that its type matches
+// the slice's element type.
+type SliceElementTestOf[T any] struct {
+	test SliceElementTest
+}
+
+// NextOf is a typed version of `c.Next` for use with a `[]T` or an array of
+// `T`. Use it with `SliceOf` so that the type of the slice is checked too:
+//
+//	contesta.SliceOf[string](c, contesta.NextOf[string](c).Is("a")).Is(s)
+func NextOf[T any](c *C) IncompleteSliceTestOf[T] {
+	return IncompleteSliceTestOf[T]{isNext: true, c: c}
+}
+
+// IdxOf is a typed version of `c.Idx` for use with a `[]T` or an array of
+// `T`.
+func IdxOf[T any](c *C, index int) IncompleteSliceTestOf[T] {
+	return IncompleteSliceTestOf[T]{index: index, c: c}
+}
+
+// Is returns a `SliceElementTestOf` which checks that the element is exactly
+// equal to the expected value.
+func (ist IncompleteSliceTestOf[T]) Is(expected T) SliceElementTestOf[T] {
+	return SliceElementTestOf[T]{SliceElementTest{
+		index:  ist.index,
+		isNext: ist.isNext,
+		test:   &ExactEqualityTester{expected},
+		caller: ist.c.Caller(),
+		callee: "contesta.IncompleteSliceTestOf.Is",
+	}}
+}
+
+// Matches returns a `SliceElementTestOf` which checks that the element matches
+// the Contester.
+func (ist IncompleteSliceTestOf[T]) Matches(test Contester) SliceElementTestOf[T] {
+	return SliceElementTestOf[T]{SliceElementTest{
+		index:  ist.index,
+		isNext: ist.isNext,
+		test:   test,
+		caller: ist.c.Caller(),
+		callee: "contesta.IncompleteSliceTestOf.Matches",
+	}}
+}
+
+func (SliceElementTestOf[T]) isSliceTest() {}
+
+func (set SliceElementTestOf[T]) Test(c *C, value reflect.Value) []*Result {
+	return set.test.Test(c, value)
+}
+
+func (set SliceElementTestOf[T]) sliceElementTest() SliceElementTest {
+	return set.test
+}
+
+// SliceTesterOf is a `*SliceTester` for a `[]T`. Its `Is` method only accepts
+// a `[]T`, so passing a slice of the wrong type is a compile error. It can also
+// be nested anywhere a Contester is accepted.
+type SliceTesterOf[T any] struct {
+	tester *SliceTester
+	c      *C
+}
+
+// SliceOf is a typed version of `c.Slice`. It only accepts tests created with
+// `NextOf[T]` and `IdxOf[T]`, so an element test for the wrong type is a
+// compile error. Call `Is` on the returned tester to run the assertion.
+//
+// As with `c.Slice`, every element must be tested. Call `NonExhaustive` on the
+// returned tester to allow untested elements.
+func SliceOf[T any](c *C, test SliceElementTestOf[T], tests ...SliceElementTestOf[T]) SliceTesterOf[T] {
+	converted := make([]SliceTest, 0, len(tests))
+	for _, t := range tests {
+		converted = append(converted, t)
+	}
+	st := c.Slice(test, converted...)
+	st.caller = c.Caller()
+	return SliceTesterOf[T]{st, c}
+}
+
+// NonExhaustive returns a copy of the tester which does not fail when some
+// elements of the slice were not tested. This is the typed equivalent of
+// passing `c.NonExhaustive()` to `c.Slice`.
+func (st SliceTesterOf[T]) NonExhaustive() SliceTesterOf[T] {
+	tester := *st.tester
+	tester.tests = withTerminator(tester.tests, SliceTest(NonExhaustive()))
+	st.tester = &tester
+	return st
+}
+
+// Is tests the slice. The final arguments follow the same rules as `c.Is`.
+func (st SliceTesterOf[T]) Is(actual []T, args ...any) bool {
+	c := st.c
+	c.t.Helper()
+	c.ResetState()
+
+	c.PushPath(c.NewPath(describeType(reflect.TypeOf(actual)), 0, "contesta.SliceTesterOf.Is"))
+	defer c.PopPath()
+
+	return c.processResults(st.tester.Test(c, actual), "Is", args)
+}
+
+func (st SliceTesterOf[T]) Test(c *C, actual any) []*Result {
+	return st.tester.Test(c, actual)
+}
+
+// MapTesterOf is a `*MapTester` for a `map[K]V`. Its `Is` method only accepts
+// a `map[K]V`, so passing a map of the wrong type is a compile error. It can
+// also be nested anywhere a Contester is accepted.
+type MapTesterOf[K comparable, V any] struct {
+	tester *MapTester
+	c      *C
+}
+
+// MapOf is a typed version of `c.Map`. It only accepts tests created with
+// `KeyOf[K, V]`, so a key test for the wrong key or value type is a compile
+// error. Call `Is` on the returned tester to run the assertion.
+//
+// As with `c.Map`, every key must be tested. Call `NonExhaustive` on the
+// returned tester to allow untested keys.
+func MapOf[K comparable, V any](c *C, test MapKeyTestOf[K, V], tests ...MapKeyTestOf[K, V]) MapTesterOf[K, V] {
+	converted := make([]MapTest, 0, len(tests))
+	for _, t := range tests {
+		converted = append(converted, t)
+	}
+	mt := c.Map(test, converted...)
+	mt.caller = c.Caller()
+	return MapTesterOf[K, V]{mt, c}
+}
+
+// NonExhaustive returns a copy of the tester which does not fail when some
+// keys in the map were not tested. This is the typed equivalent of passing
+// `c.NonExhaustive()` to `c.Map`.
+func (mt MapTesterOf[K, V]) NonExhaustive() MapTesterOf[K, V] {
+	tester := *mt.tester
+	tester.tests = withTerminator(tester.tests, MapTest(NonExhaustive()))
+	mt.tester = &tester
+	return mt
+}
+
+// Is tests the map. The final arguments follow the same rules as `c.Is`.
+func (mt MapTesterOf[K, V]) Is(actual map[K]V, args ...any) bool {
+	c := mt.c
+	c.t.Helper()
+	c.ResetState()
+
+	c.PushPath(c.NewPath(describeType(reflect.TypeOf(actual)), 0, "contesta.MapTesterOf.Is"))
+	defer c.PopPath()
+
+	return c.processResults(mt.tester.Test(c, actual), "Is", args)
+}
+
+func (mt MapTesterOf[K, V]) Test(c *C, actual any) []*Result {
+	return mt.tester.Test(c, actual)
+}
+
+// withTerminator returns a copy of the tests with any `End` or
+// `NonExhaustive` tests replaced by the given terminator.
+func withTerminator[T any](tests []T, terminator T) []T {
+	var replaced []T
+	for _, t := range tests {
+		if !isContainerTerminator(t) {
+			replaced = append(replaced, t)
+		}
+	}
+	return append(replaced, terminator)
+}
@@ -0,0 +1,175 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.True(t, Equal(c, 42, 42))
+		assert.True(t, Equal(c, []string{"a"}, []string{"a"}))
+		m.AssertPassed(t)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.False(t, Equal(c, map[string]int{"a": 1}, map[string]int{"a": 2}))
+		m.AssertFailed(t)
+	})
+
+	t.Run("nil interface", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		var err error
+		assert.True(t, Equal(c, err, nil))
+		m.AssertPassed(t)
+	})
+
+	t.Run("Require", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		Equal(c.Require(), 1, 2)
+		m.AssertFailed(t)
+		m.AssertCalled(t, "Fatal", []interface{}{"Required assertion failed: Equal"})
+	})
+}
+
+func TestGenericBuilders(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	tests := map[string]contesterTest{
+		"KeyOf": {
+			actual: map[string]int{"a": 1},
+			expect: c.Map(KeyOf[string, int](c, "a").Is(1)),
+			pass:   true,
+		},
+		"KeyOf with wrong value": {
+			actual: map[string]int{"a": 1},
+			expect: c.Map(KeyOf[string, int](c, "a").Is(2)),
+			pass:   false,
+		},
+		"KeyOf with missing key": {
+			actual: map[string]int{"a": 1},
+			expect: c.Map(KeyOf[string, int](c, "b").Is(1), NonExhaustive()),
+			pass:   false,
+		},
+		"KeyOf with wrong map type": {
+			actual: map[string]int64{"a": 1},
+			expect: c.Map(KeyOf[string, int](c, "a").Is(1)),
+			pass:   false,
+		},
+		"KeyOf Matches": {
+			actual: map[string]int{"a": 1},
+			expect: c.Map(KeyOf[string, int](c, "a").Matches(c.GreaterThan(0))),
+			pass:   true,
+		},
+		"NextOf": {
+			actual: []string{"a", "b"},
+			expect: c.Slice(NextOf[string](c).Is("a"), NextOf[string](c).Is("b")),
+			pass:   true,
+		},
+		"NextOf with wrong value": {
+			actual: []string{"a", "b"},
+			expect: c.Slice(NextOf[string](c).Is("a"), NextOf[string](c).Is("c")),
+			pass:   false,
+		},
+		"IdxOf": {
+			actual: [2]int{1, 2},
+			expect: c.Slice(IdxOf[int](c, 1).Is(2), NonExhaustive()),
+			pass:   true,
+		},
+		"IdxOf Matches": {
+			actual: []int{1, 2},
+			expect: c.Slice(IdxOf[int](c, 0).Matches(c.LessThan(2)), NextOf[int](c).Is(2)),
+			pass:   true,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("callee", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is([]int{1}, c.Slice(NextOf[int](c).Is(1)))
+		paths := res[0].Paths()
+		assert.Equal(t, "contesta.IncompleteSliceTestOf.Is", paths[len(paths)-1].callee)
+	})
+}
+
+func TestTypedTesters(t *testing.T) {
+	t.Run("SliceOf passes", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.True(t, SliceOf[string](c, NextOf[string](c).Is("a"), NextOf[string](c).Is("b")).Is([]string{"a", "b"}))
+		m.AssertPassed(t)
+	})
+
+	t.Run("SliceOf fails", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.False(t, SliceOf[int](c, NextOf[int](c).Is(1)).Is([]int{2}, "ints"))
+		m.AssertFailed(t)
+	})
+
+	t.Run("MapOf passes", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.True(t, MapOf[string, int](c, KeyOf[string, int](c, "a").Is(1)).Is(map[string]int{"a": 1}))
+		m.AssertPassed(t)
+	})
+
+	t.Run("MapOf fails", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.False(t, MapOf[string, int](c, KeyOf[string, int](c, "a").Is(1)).Is(map[string]int{"a": 2}))
+		m.AssertFailed(t)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		MapOf[string, []int](
+			c,
+			KeyOf[string, []int](c, "a").Matches(SliceOf[int](c, NextOf[int](c).Is(1))),
+		).Is(map[string][]int{"a": {1}})
+		m.AssertPassed(t)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "[]int"})
+		res := c.is([]int{2}, SliceOf[int](c, NextOf[int](c).Is(1)))
+		if assert.Len(t, res, 2) {
+			paths := res[0].Paths()
+			if assert.Len(t, paths, 2) {
+				// The caller for the slice is the test, not SliceOf.
+				assert.Equal(t, "contesta.TestTypedTesters.func6", paths[0].caller)
+				assert.Equal(t, "[0]", paths[1].data)
+			}
+		}
+	})
+
+	t.Run("End is the default", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.False(t, SliceOf[int](c, NextOf[int](c).Is(1)).Is([]int{1, 2}))
+		assert.False(t, MapOf[string, int](c, KeyOf[string, int](c, "a").Is(1)).Is(map[string]int{"a": 1, "b": 2}))
+		m.AssertFailed(t)
+	})
+
+	t.Run("NonExhaustive", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		assert.True(t, SliceOf[int](c, NextOf[int](c).Is(1)).NonExhaustive().Is([]int{1, 2}))
+		assert.True(
+			t,
+			MapOf[string, int](c, KeyOf[string, int](c, "a").Is(1)).NonExhaustive().Is(map[string]int{"a": 1, "b": 2}),
+		)
+		m.AssertPassed(t)
+	})
+}
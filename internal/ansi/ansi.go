@@ -2,6 +2,9 @@ package ansi
 
 import "regexp"
 
+// Scheme is a set of functions used to style output. Each function takes a
+// string and returns it wrapped in escape codes, or unchanged for a scheme
+// without color.
 type Scheme struct {
 	Strong    func(string) string
 	Em        func(string) string
@@ -10,6 +13,7 @@ type Scheme struct {
 	Warning   func(string) string
 }
 
+// DefaultScheme uses 256-color escape codes.
 var DefaultScheme = Scheme{
 	Strong:    bold,
 	Em:        em,
@@ -18,6 +22,25 @@ var DefaultScheme = Scheme{
 	Warning:   orange,
 }
 
+// BasicScheme only uses the 16 basic colors, which are supported by almost
+// every terminal.
+var BasicScheme = Scheme{
+	Strong:    bold,
+	Em:        em,
+	Correct:   basicGreen,
+	Incorrect: basicRed,
+	Warning:   basicYellow,
+}
+
+// PlainScheme does not style anything.
+var PlainScheme = Scheme{
+	Strong:    plain,
+	Em:        plain,
+	Correct:   plain,
+	Incorrect: plain,
+	Warning:   plain,
+}
+
 const endEscape = "\033[0m"
 
 func bold(s string) string {
@@ -28,16 +51,34 @@ func em(s string) string {
 	return "\033[7m" + s + endEscape
 }
 
+// The 256-color codes use semicolons as separators rather than colons. Colons
+// are what the standard specifies but many terminals only support semicolons.
 func green(s string) string {
-	return "\033[38:5:2m" + s + endEscape
+	return "\033[38;5;2m" + s + endEscape
 }
 
 func red(s string) string {
-	return "\033[38:5:9m" + s + endEscape
+	return "\033[38;5;9m" + s + endEscape
 }
 
 func orange(s string) string {
-	return "\033[38:5:208m" + s + endEscape
+	return "\033[38;5;208m" + s + endEscape
+}
+
+func basicGreen(s string) string {
+	return "\033[32m" + s + endEscape
+}
+
+func basicRed(s string) string {
+	return "\033[91m" + s + endEscape
+}
+
+func basicYellow(s string) string {
+	return "\033[33m" + s + endEscape
+}
+
+func plain(s string) string {
+	return s
 }
 
 // Copied from github.com/apcera/termtables/cell.go with a fix to allow a
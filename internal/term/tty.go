@@ -0,0 +1,19 @@
+package term
+
+import (
+	"os"
+)
+
+// IsTerminal returns true if the writer is an `*os.File` connected to a
+// terminal.
+func IsTerminal(w any) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -3,6 +3,8 @@ package contesta
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 type MapTester struct {
@@ -12,55 +14,49 @@ type MapTester struct {
 
 type MapTest interface {
 	isMapTest()
-	Test(c *C, value reflect.Value) []*result
+	Test(c *C, value reflect.Value) []*Result
 }
 
+// Map takes one or more `MapTest` values and returns a `*MapTester`. If
+// neither `c.End()` nor `c.NonExhaustive()` is given then `c.End()` is added,
+// so by default every key in the map must be tested. The `End` and
+// `NonExhaustive` tests are always run after all of the other tests.
 func (c *C) Map(test MapTest, tests ...MapTest) *MapTester {
 	tests = append([]MapTest{test}, tests...)
 
-	// var hasEnd bool
-	// for _, t := range tests {
-	// 	if _, ok := t.(ExhaustiveContainerTest); ok {
-	// 		hasEnd = true
-	// 		break
-	// 	}
-	// 	if _, ok := t.(NonExhaustiveContainerTest); ok {
-	// 		hasEnd = true
-	// 		break
-	// 	}
-	// }
-	// if !hasEnd {
-	// 	tests = append(tests, End())
-	// }
+	var ordered, terminators []MapTest
+	for _, t := range tests {
+		if isContainerTerminator(t) {
+			terminators = append(terminators, t)
+		} else {
+			ordered = append(ordered, t)
+		}
+	}
+	if len(terminators) == 0 {
+		terminators = append(terminators, End())
+	}
 
 	return &MapTester{
-		tests:  tests,
+		tests:  append(ordered, terminators...),
 		caller: c.Caller(),
 	}
 }
 
-func (mt *MapTester) Test(c *C, actual any) []*result {
+func (mt *MapTester) Test(c *C, actual any) []*Result {
 	c.SetCaller(mt.caller)
 	defer c.UnsetCaller()
 
 	vt := reflect.TypeOf(actual)
-	if vt.Kind() != reflect.Map {
-		actualKind := vt.Kind()
-		return []*result{{
-			pass: false,
-			description: fmt.Sprintf(
-				"Expected a map but got %s %s",
-				Article(actualKind.String()),
-				actualKind,
-			),
-			paths: c.Paths(),
-			where: inType,
-		}}
+	if vt == nil || vt.Kind() != reflect.Map {
+		return unexpectedKindResult(c, "a map", vt)
 	}
 
 	va := reflect.ValueOf(actual)
 
-	var res []*result
+	c.pushContainer()
+	defer c.popContainer()
+
+	var res []*Result
 	for _, t := range mt.tests {
 		res = append(res, t.Test(c, va)...)
 	}
@@ -75,26 +71,195 @@ type IncompleteMapKeyTest struct {
 
 type MapKeyTest struct {
 	key    any
+	check  keyCheck
 	test   any
 	caller string
+	// callee overrides the callee shown for the key's path element. This is
+	// used by `KeyOf`.
+	callee string
+}
+
+// keyCheck is the type of check that a `MapKeyTest` does.
+type keyCheck int
+
+const (
+	keyIs keyCheck = iota
+	keyExists
+	keyMissing
+)
+
+// method returns the name of the `IncompleteMapKeyTest` method that creates a
+// `MapKeyTest` with this check.
+func (kc keyCheck) method() string {
+	switch kc {
+	case keyExists:
+		return "Exists"
+	case keyMissing:
+		return "Missing"
+	case keyIs:
+	}
+	return "Is"
 }
 
 func (c *C) Key(key any) IncompleteMapKeyTest {
 	return IncompleteMapKeyTest{key, c}
 }
 
+// Is returns a `MapKeyTest` which checks that the key exists and that its
+// value matches the expected value.
 func (imt IncompleteMapKeyTest) Is(expected any) MapKeyTest {
 	return MapKeyTest{
 		key:    imt.key,
+		check:  keyIs,
 		test:   expected,
 		caller: imt.c.Caller(),
 	}
 }
 
+// Exists returns a `MapKeyTest` which checks that the key exists in the map,
+// regardless of its value.
+func (imt IncompleteMapKeyTest) Exists() MapKeyTest {
+	return MapKeyTest{
+		key:    imt.key,
+		check:  keyExists,
+		caller: imt.c.Caller(),
+	}
+}
+
+// Missing returns a `MapKeyTest` which checks that the key does not exist in
+// the map. This is different from checking that the key's value is the zero
+// value for the map's value type.
+func (imt IncompleteMapKeyTest) Missing() MapKeyTest {
+	return MapKeyTest{
+		key:    imt.key,
+		check:  keyMissing,
+		caller: imt.c.Caller(),
+	}
+}
+
 func (MapKeyTest) isMapTest() {}
 
-func (mt MapKeyTest) Test(c *C, value reflect.Value) []*result {
-	return c.is(value.MapIndex(reflect.ValueOf(mt.key)).Interface(), mt.test)
+func (mt MapKeyTest) Test(c *C, value reflect.Value) []*Result {
+	callee := mt.callee
+	if callee == "" {
+		callee = "contesta.IncompleteMapKeyTest." + mt.check.method()
+	}
+	c.PushPath(Path{
+		data:   keyPathData(reflect.ValueOf(mt.key)),
+		callee: callee,
+		caller: mt.caller,
+	})
+	defer c.PopPath()
+
+	key, desc := mapKeyValue(mt.key, value.Type())
+	if desc != "" {
+		return []*Result{{
+			pass:        false,
+			description: desc,
+			paths:       c.Paths(),
+			where:       inUsage,
+		}}
+	}
+
+	v := value.MapIndex(key)
+	switch mt.check {
+	case keyExists:
+		if !v.IsValid() {
+			return []*Result{missingKeyResult(c, key, value)}
+		}
+		c.markChecked(key.Interface())
+		return []*Result{{
+			pass:        true,
+			description: fmt.Sprintf("key %s exists", describeKey(key)),
+			paths:       c.Paths(),
+		}}
+	case keyMissing:
+		if v.IsValid() {
+			return []*Result{{
+				pass:        false,
+				actual:      newValue(v.Interface()),
+				description: fmt.Sprintf("key %s exists but should not", describeKey(key)),
+				paths:       c.Paths(),
+				where:       inDataStructure,
+			}}
+		}
+		return []*Result{{
+			pass:        true,
+			description: fmt.Sprintf("key %s does not exist", describeKey(key)),
+			paths:       c.Paths(),
+		}}
+	case keyIs:
+	}
+
+	if !v.IsValid() {
+		return []*Result{missingKeyResult(c, key, value)}
+	}
+
+	c.markChecked(key.Interface())
+	return c.is(v.Interface(), mt.test)
+}
+
+// mapKeyValue returns a `reflect.Value` for the key that can be used to look
+// up a value in a map with the given type. If the key cannot be used with the
+// map then it returns a description of the problem.
+func mapKeyValue(key any, mapType reflect.Type) (reflect.Value, string) {
+	keyType := mapType.Key()
+
+	kv := reflect.ValueOf(key)
+	if !kv.IsValid() {
+		if isNilable(keyType.Kind()) {
+			return reflect.Zero(keyType), ""
+		}
+	} else if !kv.Comparable() {
+		// Looking up a key which cannot be hashed, like a slice, panics.
+		return kv, cannotUseKeyMessage(kv, mapType)
+	} else if kv.Type().AssignableTo(keyType) {
+		return kv, ""
+	} else if isOrderedNumber(kv) && isOrderedNumber(reflect.Zero(keyType)) {
+		// This lets an untyped constant like `c.Key(1)` be used with a
+		// `map[int64]V`, as long as converting the key does not change it.
+		if converted, ok := convertNumber(kv, keyType); ok {
+			return converted, ""
+		}
+		return kv, fmt.Sprintf(
+			"Cannot use %v as a key for %s without changing its value",
+			kv,
+			articleize(describeType(mapType)),
+		)
+	} else if kv.Kind() == keyType.Kind() && kv.Type().ConvertibleTo(keyType) {
+		return kv.Convert(keyType), ""
+	}
+
+	return kv, cannotUseKeyMessage(kv, mapType)
+}
+
+func cannotUseKeyMessage(key reflect.Value, mapType reflect.Type) string {
+	return fmt.Sprintf(
+		"Cannot use %s as a key for %s",
+		articleize(describeTypeOfReflectValue(key)),
+		articleize(describeType(mapType)),
+	)
+}
+
+func missingKeyResult(c *C, key, value reflect.Value) *Result {
+	var keys []string
+	for _, k := range sortedMapKeys(value) {
+		keys = append(keys, describeKey(k))
+	}
+
+	desc := fmt.Sprintf("key %s does not exist", describeKey(key))
+	if len(keys) == 0 {
+		desc += " (the map is empty)"
+	} else {
+		desc += fmt.Sprintf(" (available keys: %s)", strings.Join(keys, ", "))
+	}
+
+	return &Result{
+		pass:        false,
+		description: desc,
+		paths:       c.Paths(),
+		where:       inDataStructure,
+	}
 }
 
 type MapKeysNotCheckedFailure struct {
@@ -102,5 +267,155 @@ type MapKeysNotCheckedFailure struct {
 }
 
 func (mknf MapKeysNotCheckedFailure) Failure() string {
-	return fmt.Sprintf("%d keys in the map were not checked: %v", len(mknf.keys), mknf.keys)
+	return fmt.Sprintf(
+		"%s not checked: %v",
+		countOf(len(mknf.keys), "key in the map", "keys in the map"),
+		mknf.keys,
+	)
+}
+
+// sortedMapKeys returns the keys of the map in a stable order so that output
+// which includes keys is the same every time.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return fmt.Sprintf("%v", keys[i]) < fmt.Sprintf("%v", keys[j])
+	})
+	return keys
+}
+
+// keyPathData returns the data path element for a map key, like `["foo"]` or
+// `[42]`.
+func keyPathData(key reflect.Value) string {
+	return "[" + describeKey(key) + "]"
+}
+
+// describeKey returns a string for a map key. String keys are quoted.
+func describeKey(key reflect.Value) string {
+	if !key.IsValid() {
+		return "nil"
+	}
+	if key.Kind() == reflect.Interface && !key.IsNil() {
+		key = key.Elem()
+	}
+	if key.Kind() == reflect.String {
+		return fmt.Sprintf("%q", key.String())
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+// IncompleteKeyMatchingTest is returned by `c.KeyMatching`. Call its `Is`
+// method to turn it into a `KeyMatchingTest`.
+type IncompleteKeyMatchingTest struct {
+	keyTest any
+	c       *C
+}
+
+// KeyMatchingTest applies a test to the value of every key in a map which
+// matches a key test.
+type KeyMatchingTest struct {
+	keyTest any
+	test    any
+	caller  string
+}
+
+// KeyMatching returns an `IncompleteKeyMatchingTest` for every key in the map
+// which matches the key test. The key test can be either a literal value or a
+// Contester, for example `c.KeyMatching(c.HasPrefix("user-"))`. This is useful
+// for maps keyed by generated IDs or timestamps, where the exact keys are not
+// known in advance.
+func (c *C) KeyMatching(keyTest any) IncompleteKeyMatchingTest {
+	return IncompleteKeyMatchingTest{keyTest, c}
+}
+
+// Is returns a `KeyMatchingTest` which checks that at least one key matches
+// the key test, and that the value of every matching key matches the expected
+// value. Every matching key is marked as checked.
+func (imt IncompleteKeyMatchingTest) Is(expected any) KeyMatchingTest {
+	return KeyMatchingTest{
+		keyTest: imt.keyTest,
+		test:    expected,
+		caller:  imt.c.Caller(),
+	}
+}
+
+func (KeyMatchingTest) isMapTest() {}
+
+func (kmt KeyMatchingTest) Test(c *C, value reflect.Value) []*Result {
+	var res []*Result
+	for _, k := range sortedMapKeys(value) {
+		if !allPassed(c.is(k.Interface(), kmt.keyTest)) {
+			continue
+		}
+
+		c.PushPath(Path{
+			data:   keyPathData(k),
+			callee: "contesta.IncompleteKeyMatchingTest.Is",
+			caller: kmt.caller,
+		})
+		c.markChecked(k.Interface())
+		res = append(res, c.is(value.MapIndex(k).Interface(), kmt.test)...)
+		c.PopPath()
+	}
+
+	if len(res) == 0 {
+		return []*Result{c.FailStructure(fmt.Sprintf(
+			"None of the keys in the map matched the key test: %s checked",
+			countOf(value.Len(), "key", "keys"),
+		))}
+	}
+
+	return res
+}
+
+// MapEachTest applies a test to every key or every value in a map.
+type MapEachTest struct {
+	keys   bool
+	test   any
+	caller string
+}
+
+// AllKeys returns a `MapEachTest` which checks that every key in the map
+// matches the expected value, which can be either a literal value or a
+// Contester. Every key is marked as checked.
+func (c *C) AllKeys(expected any) MapEachTest {
+	return MapEachTest{keys: true, test: expected, caller: c.Caller()}
+}
+
+// AllValues returns a `MapEachTest` which checks that every value in the map
+// matches the expected value, which can be either a literal value or a
+// Contester. Every key is marked as checked.
+func (c *C) AllValues(expected any) MapEachTest {
+	return MapEachTest{keys: false, test: expected, caller: c.Caller()}
+}
+
+func (MapEachTest) isMapTest() {}
+
+func (met MapEachTest) Test(c *C, value reflect.Value) []*Result {
+	callee := "contesta.(*C).AllValues"
+	if met.keys {
+		callee = "contesta.(*C).AllKeys"
+	}
+
+	var res []*Result
+	for _, k := range sortedMapKeys(value) {
+		c.PushPath(Path{data: keyPathData(k), callee: callee, caller: met.caller})
+		c.markChecked(k.Interface())
+		if met.keys {
+			res = append(res, c.is(k.Interface(), met.test)...)
+		} else {
+			res = append(res, c.is(value.MapIndex(k).Interface(), met.test)...)
+		}
+		c.PopPath()
+	}
+
+	if len(res) == 0 {
+		return []*Result{{
+			pass:        true,
+			description: "the map is empty",
+			paths:       c.Paths(),
+		}}
+	}
+
+	return res
 }
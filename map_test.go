@@ -0,0 +1,259 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("key matches", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]int{"foo": 42}, c.Map(c.Key("foo").Is(42)))
+		m.AssertPassed(t)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(map[string]int{"bar": 1, "baz": 0}, c.Map(c.Key("foo").Is(42), NonExhaustive()))
+		if assert.Len(t, res, 2) {
+			assert.False(t, res[0].pass, "missing key fails")
+			assert.Equal(t, inDataStructure, res[0].where)
+			assert.Equal(
+				t,
+				`key "foo" does not exist (available keys: "bar", "baz")`,
+				res[0].description,
+			)
+		}
+	})
+	t.Run("key of the wrong type", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]int{"foo": 42}, c.Map(c.Key(42).Is(42), NonExhaustive()))
+		m.AssertFailed(t)
+	})
+	t.Run("Exists", func(t *testing.T) {
+		t.Run("key with zero value", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(map[string]int{"foo": 0}, c.Map(c.Key("foo").Exists()))
+			m.AssertPassed(t)
+		})
+		t.Run("missing key", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(map[string]int{}, c.Map(c.Key("foo").Exists()))
+			m.AssertFailed(t)
+		})
+	})
+	t.Run("Missing", func(t *testing.T) {
+		t.Run("missing key", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(map[string]int{}, c.Map(c.Key("foo").Missing()))
+			m.AssertPassed(t)
+		})
+		t.Run("key with zero value", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(map[string]int{"foo": 0}, c.Map(c.Key("foo").Missing(), NonExhaustive()))
+			m.AssertFailed(t)
+		})
+	})
+	t.Run("nested path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "map[string]map[string]int"})
+		res := c.is(
+			map[string]map[string]int{"foo": {"bar": 42}},
+			c.Map(c.Key("foo").Is(c.Map(c.Key("bar").Is(43)))),
+		)
+		AssertResultsAre(
+			t,
+			outputItems(res),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"map[string]map[string]int", `["foo"]`, `["bar"]`}},
+				{pass: true, dataPath: []string{"map[string]map[string]int", `["foo"]`}},
+				{pass: true, dataPath: []string{"map[string]map[string]int"}},
+			},
+			"nested maps",
+		)
+		assert.Equal(
+			t,
+			"contesta.TestMap.func6 called contesta.IncompleteMapKeyTest.Is",
+			res[0].paths[2].CalledAt(),
+			"caller for key path is where the key test was created",
+		)
+	})
+}
+
+func TestMapMatchers(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	users := map[string]int{"user-1": 10, "user-2": 20, "admin": 0}
+
+	tests := map[string]contesterTest{
+		"KeyMatching": {
+			actual: users,
+			expect: c.Map(c.KeyMatching(c.HasPrefix("user-")).Is(c.GreaterThan(0)), c.Key("admin").Is(0)),
+			pass:   true,
+		},
+		"KeyMatching with failing value": {
+			actual: users,
+			expect: c.Map(c.KeyMatching(c.HasPrefix("user-")).Is(10), c.Key("admin").Is(0)),
+			pass:   false,
+		},
+		"KeyMatching with no matching keys": {
+			actual: users,
+			expect: c.Map(c.KeyMatching(c.HasPrefix("group-")).Is(0), NonExhaustive()),
+			pass:   false,
+		},
+		"KeyMatching leaves other keys unchecked": {
+			actual: users,
+			expect: c.Map(c.KeyMatching(c.HasPrefix("user-")).Is(c.GreaterThan(0))),
+			pass:   false,
+		},
+		"KeyMatching with literal key": {
+			actual: users,
+			expect: c.Map(c.KeyMatching("admin").Is(0), NonExhaustive()),
+			pass:   true,
+		},
+		"AllKeys": {
+			actual: users,
+			expect: c.Map(c.AllKeys(c.Like(`^[a-z]+(?:-\d+)?$`))),
+			pass:   true,
+		},
+		"AllKeys fails": {
+			actual: users,
+			expect: c.Map(c.AllKeys(c.HasPrefix("user-"))),
+			pass:   false,
+		},
+		"AllValues": {
+			actual: users,
+			expect: c.Map(c.AllValues(c.GreaterOrEqual(0))),
+			pass:   true,
+		},
+		"AllValues fails": {
+			actual: users,
+			expect: c.Map(c.AllValues(c.GreaterThan(0))),
+			pass:   false,
+		},
+		"AllValues on empty map": {
+			actual: map[string]int{},
+			expect: c.Map(c.AllValues(c.GreaterThan(0))),
+			pass:   true,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("paths", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "map[string]int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is(users, c.Map(c.KeyMatching(c.HasPrefix("user-")).Is(20), NonExhaustive()))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"map[string]int", `["user-1"]`}},
+				{pass: true, dataPath: []string{"map[string]int", `["user-2"]`}},
+				{pass: true, dataPath: []string{"map[string]int"}},
+			},
+			"KeyMatching",
+		)
+	})
+
+	t.Run("KeyMatching with no matching keys description", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(map[string]int{"admin": 0}, c.Map(c.KeyMatching(c.HasPrefix("user-")).Is(0), NonExhaustive()))
+		if assert.Len(t, res, 2) {
+			assert.Equal(
+				t,
+				"None of the keys in the map matched the key test: 1 key was checked",
+				res[0].Description(),
+			)
+		}
+	})
+}
+
+func TestMapNumericKeys(t *testing.T) {
+	for name, test := range map[string]struct {
+		actual any
+		key    any
+		pass   bool
+	}{
+		"int key for int64 map":      {map[int64]string{1: "a"}, 1, true},
+		"int key for uint8 map":      {map[uint8]string{1: "a"}, 1, true},
+		"int key for float64 map":    {map[float64]string{1: "a"}, 1, true},
+		"float key for int map":      {map[int]string{1: "a"}, 1.0, true},
+		"fractional key for int map": {map[int]string{1: "a"}, 1.5, false},
+		"negative key for uint map":  {map[uint]string{1: "a"}, -1, false},
+		"too large for int8 map":     {map[int8]string{1: "a"}, 257, false},
+	} {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(test.actual, c.Map(c.Key(test.key).Is("a")), name)
+			if test.pass {
+				m.AssertPassed(t)
+			} else {
+				m.AssertFailed(t)
+			}
+		})
+	}
+
+	c := NewWithOutput(newMockT(), newMockT())
+	c.ResetState()
+	res := c.is(map[uint]string{1: "a"}, c.Map(c.Key(-1).Is("a"), NonExhaustive()))
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, inUsage, res[0].where)
+		assert.Equal(
+			t,
+			"Cannot use -1 as a key for a map[uint]string without changing its value",
+			res[0].description,
+		)
+	}
+}
+
+func TestMapUnhashableKey(t *testing.T) {
+	m := newMockT()
+	c := NewWithOutput(m, m)
+	c.Is(map[any]int{1: 1}, c.Map(c.Key([]int{1}).Is(1)))
+	m.AssertFailed(t)
+
+	c.ResetState()
+	res := c.is(map[any]int{1: 1}, c.Map(c.Key([]int{1}).Exists(), NonExhaustive()))
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, inUsage, res[0].where)
+		assert.Equal(t, "Cannot use a []int as a key for a map[interface {}]int", res[0].description)
+	}
+}
+
+func TestMapEnd(t *testing.T) {
+	t.Run("unchecked map keys", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.ResetState()
+		res := c.is(map[string]int{"a": 1, "b": 2, "c": 3}, c.Map(c.Key("b").Is(2)))
+		if assert.Len(t, res, 2) {
+			assert.False(t, res[1].pass, "End failed")
+			assert.Equal(t, `2 keys in the map were not checked: [a c]`, res[1].description)
+		}
+	})
+	t.Run("all map keys checked", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]int{"a": 1}, c.Map(c.Key("a").Is(1), End()))
+		m.AssertPassed(t)
+	})
+	t.Run("one unchecked map key", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(map[string]int{"a": 1, "b": 2}, c.Map(c.Key("b").Is(2)))
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, `1 key in the map was not checked: [a]`, res[1].description)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package contesta
+
+import (
+	"strings"
+)
+
+// NotTester inverts the results of another test. It passes if the wrapped test
+// fails, and fails if the wrapped test passes. A literal value whose type
+// differs from the actual value, like `nil` for a non-nil pointer, does not
+// match, so it passes. A failure which means the test could not be applied,
+// like `Like` given an int or an invalid pattern, is not inverted, so it still
+// fails.
+type NotTester struct {
+	expect any
+}
+
+// Not takes either a literal value or a Contester and returns a `*NotTester`
+// which passes when the actual value does not match. This can be used anywhere
+// a Contester is accepted, for example `c.Key("name").Is(c.Not(""))`.
+func (c *C) Not(expected any) *NotTester {
+	return &NotTester{expected}
+}
+
+func (nt *NotTester) Test(c *C, actual any) []*Result {
+	return c.negate(actual, nt.expect, c.is(actual, nt.expect))
+}
+
+// negate turns the results of applying the expected value to the actual value
+// into a single result which passes if any of the results failed. Usage
+// failures, and type failures other than a mismatch between two compared
+// values, are returned unchanged, since they mean that the test could not be
+// applied at all, not that the value did not match.
+func (c *C) negate(actual, expected any, results []*Result) []*Result {
+	var unusable []*Result
+	for _, r := range results {
+		if !r.pass && (r.where == inType && !r.mismatch || r.where == inUsage) {
+			unusable = append(unusable, r)
+		}
+	}
+	if len(unusable) > 0 {
+		return unusable
+	}
+
+	// A single result for the value itself can be shown with its own expected
+	// value and operator. Otherwise the results are for different parts of
+	// the value, so we show the wrapped value or test instead. A literal value
+	// is compared to the whole value with "==".
+	expect := expected
+	var op string
+	if len(results) == 1 && results[0].expect != nil && len(results[0].paths) <= len(c.state.paths) {
+		expect = results[0].expect.value
+		op = results[0].op
+	} else if _, ok := expected.(Contester); !ok {
+		op = "=="
+	}
+	op = negateOp(op)
+
+	for _, r := range results {
+		if !r.pass {
+			return []*Result{c.Pass(actual, expect, op)}
+		}
+	}
+
+	res := c.FailValue(actual, expect, op)
+	if len(results) > 1 {
+		res.description = "The value matched all of the tests but should not have"
+	}
+	return []*Result{res}
+}
+
+var negatedOps = map[string]string{
+	"==": "!=",
+	"!=": "==",
+	"=~": "!~",
+	"!~": "=~",
+}
+
+// negateOp returns the inverse of an operator. An equality or match operator
+// is swapped wherever it appears, so "==" becomes "!=", "len() ==" becomes
+// "len() !=", and "=~" becomes "!~". Other operators are negated with "not",
+// so "matching" becomes "not matching".
+func negateOp(op string) string {
+	if op == "" {
+		return "not"
+	}
+
+	words := strings.Split(op, " ")
+	for i, w := range words {
+		if neg, ok := negatedOps[w]; ok {
+			words[i] = neg
+			return strings.Join(words, " ")
+		}
+	}
+
+	if strings.HasPrefix(op, "not ") {
+		return strings.TrimPrefix(op, "not ")
+	}
+	return "not " + op
+}
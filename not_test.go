@@ -0,0 +1,168 @@
+package contesta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNot(t *testing.T) {
+	t.Run("Isnt", func(t *testing.T) {
+		t.Run("42 != 43", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Isnt(42, 43)
+			m.AssertPassed(t)
+		})
+		t.Run("42 != 42", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Isnt(42, 42)
+			m.AssertFailed(t)
+		})
+		t.Run("non-nil pointer != nil", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			x := 42
+			c.Isnt(&x, nil)
+			m.AssertPassed(t)
+		})
+		t.Run("nil pointer != nil", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			var p *int
+			c.Isnt(p, nil)
+			m.AssertFailed(t)
+		})
+		t.Run("error != nil", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Isnt(errors.New("oops"), nil)
+			m.AssertPassed(t)
+		})
+		t.Run("nil error != nil", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			var err error
+			c.Isnt(err, nil)
+			m.AssertFailed(t)
+		})
+	})
+	t.Run("values of different types do not match", func(t *testing.T) {
+		type S struct{ V any }
+		c := NewWithOutput(newMockT(), newMockT())
+		x := 42
+		for name, test := range map[string]struct {
+			actual any
+			expect Contester
+		}{
+			"Not(nil) with pointer":    {&x, c.Not(nil)},
+			"NoneOf(nil) with pointer": {&x, c.NoneOf(nil)},
+			"Not with int and string":  {42, c.Not("42")},
+			"Not with struct fields":   {S{1}, c.Not(S{"1"})},
+		} {
+			t.Run(name, func(t *testing.T) {
+				c.ResetState()
+				res := c.is(test.actual, test.expect)
+				if assert.Len(t, res, 1) {
+					assert.True(t, res[0].pass)
+					assert.Equal(t, "!=", res[0].op)
+				}
+			})
+		}
+	})
+	t.Run("nested in a map key", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]string{"name": ""}, c.Map(c.Key("name").Is(c.Not(""))))
+		m.AssertFailed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.Is(map[string]string{"name": "Joe"}, c.Map(c.Key("name").Is(c.Not(""))))
+		m.AssertPassed(t)
+	})
+	t.Run("op", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(42, c.Not(42))
+		if assert.Len(t, res, 1) {
+			assert.False(t, res[0].pass)
+			assert.Equal(t, "!=", res[0].op)
+		}
+	})
+	t.Run("nested results", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		mt := c.Map(c.Key("a").Is(1))
+		res := c.is(map[string]int{"a": 1}, c.Not(mt))
+		if assert.Len(t, res, 1) {
+			assert.False(t, res[0].pass)
+			assert.Equal(t, "not", res[0].op)
+			expect, _ := res[0].Expect()
+			assert.Equal(t, mt, expect)
+			actual, _ := res[0].Actual()
+			assert.Equal(t, map[string]int{"a": 1}, actual)
+		}
+
+		c.ResetState()
+		res = c.is([]int{1, 2}, c.Not([]int{1, 2}))
+		if assert.Len(t, res, 1) {
+			assert.False(t, res[0].pass)
+			assert.Equal(t, "!=", res[0].op)
+			expect, _ := res[0].Expect()
+			assert.Equal(t, []int{1, 2}, expect)
+		}
+	})
+	t.Run("Empty op", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is([]int{}, c.Not(c.Empty()))
+		if assert.Len(t, res, 1) {
+			assert.False(t, res[0].pass)
+			assert.Equal(t, "len() !=", res[0].op)
+		}
+	})
+	t.Run("usage and type failures are not inverted", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		for name, test := range map[string]struct {
+			actual any
+			expect Contester
+			where  failure
+		}{
+			"Not with invalid pattern":       {"x", c.Not(c.Like("(")), inUsage},
+			"Not with wrong type":            {42, c.Not(c.Like("x")), inType},
+			"NoneOf with invalid pattern":    {"x", c.NoneOf(c.Like("(")), inUsage},
+			"NoneOf with wrong type":         {42, c.NoneOf(c.Like("x")), inType},
+			"NoElement with invalid pattern": {[]string{"x"}, c.NoElement(c.Like("(")), inUsage},
+			"NoElement with wrong type":      {[]int{42}, c.NoElement(c.Like("x")), inType},
+		} {
+			c.ResetState()
+			res := c.is(test.actual, test.expect)
+			if assert.Len(t, res, 1, name) {
+				assert.False(t, res[0].pass, name)
+				assert.Equal(t, test.where, res[0].where, name)
+			}
+
+			m := newMockT()
+			mc := NewWithOutput(m, m)
+			mc.Is(test.actual, test.expect)
+			m.AssertFailed(t)
+		}
+	})
+}
+
+func Test_negateOp(t *testing.T) {
+	assert.Equal(t, "!=", negateOp("=="))
+	assert.Equal(t, "==", negateOp("!="))
+	assert.Equal(t, "!= (value)", negateOp("== (value)"))
+	assert.Equal(t, "!~", negateOp("=~"))
+	assert.Equal(t, "not matching", negateOp("matching"))
+	assert.Equal(t, "matching", negateOp("not matching"))
+	assert.Equal(t, "not", negateOp(""))
+	assert.Equal(t, "len() !=", negateOp("len() =="))
+	assert.Equal(t, "len() ==", negateOp("len() !="))
+	assert.Equal(t, "not >", negateOp(">"))
+	assert.Equal(t, "not ≈ ±0.1", negateOp("≈ ±0.1"))
+}
@@ -0,0 +1,291 @@
+package contesta
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// NumericTester compares a number against one or more bounds. The actual
+// value and the bounds may be of different numeric types. They are converted
+// using the same rules as `c.ValueIs` before being compared.
+type NumericTester struct {
+	expect any
+	op     string
+	bounds []any
+	check  func(actual reflect.Value) (bool, string)
+}
+
+// Approx returns a `*NumericTester` which passes if the actual value is within
+// `epsilon` of the expected value.
+func (c *C) Approx(expected, epsilon any) *NumericTester {
+	return &NumericTester{
+		expect: expected,
+		op:     fmt.Sprintf("≈ ±%v", epsilon),
+		bounds: []any{expected, epsilon},
+		check: func(actual reflect.Value) (bool, string) {
+			return approxEqual(actual, reflect.ValueOf(expected), reflect.ValueOf(epsilon))
+		},
+	}
+}
+
+// WithinRel returns a `*NumericTester` which passes if the actual value is
+// within `pct` percent of the expected value. For example, `c.WithinRel(200,
+// 5)` passes for any value from 190 to 210.
+func (c *C) WithinRel(expected, pct any) *NumericTester {
+	return &NumericTester{
+		expect: expected,
+		op:     fmt.Sprintf("≈ ±%v%%", pct),
+		bounds: []any{expected, pct},
+		check: func(actual reflect.Value) (bool, string) {
+			ev := reflect.ValueOf(expected)
+			epsilon := toFloat64(ev) * toFloat64(reflect.ValueOf(pct)) / 100
+			return approxEqual(actual, ev, reflect.ValueOf(epsilon))
+		},
+	}
+}
+
+// Between returns a `*NumericTester` which passes if the actual value is
+// greater than or equal to `lo` and less than or equal to `hi`.
+func (c *C) Between(lo, hi any) *NumericTester {
+	return &NumericTester{
+		expect: fmt.Sprintf("[%v, %v]", lo, hi),
+		op:     "between",
+		bounds: []any{lo, hi},
+		check: func(actual reflect.Value) (bool, string) {
+			cmp, desc := compareNumbers(actual, reflect.ValueOf(lo))
+			if desc != "" || cmp < 0 {
+				return false, desc
+			}
+			cmp, desc = compareNumbers(actual, reflect.ValueOf(hi))
+			return desc == "" && cmp <= 0, desc
+		},
+	}
+}
+
+// GreaterThan returns a `*NumericTester` which passes if the actual value is
+// greater than `n`.
+func (c *C) GreaterThan(n any) *NumericTester {
+	return comparisonTester(n, ">", func(cmp int) bool { return cmp > 0 })
+}
+
+// GreaterOrEqual returns a `*NumericTester` which passes if the actual value
+// is greater than or equal to `n`.
+func (c *C) GreaterOrEqual(n any) *NumericTester {
+	return comparisonTester(n, ">=", func(cmp int) bool { return cmp >= 0 })
+}
+
+// LessThan returns a `*NumericTester` which passes if the actual value is
+// less than `n`.
+func (c *C) LessThan(n any) *NumericTester {
+	return comparisonTester(n, "<", func(cmp int) bool { return cmp < 0 })
+}
+
+// LessOrEqual returns a `*NumericTester` which passes if the actual value is
+// less than or equal to `n`.
+func (c *C) LessOrEqual(n any) *NumericTester {
+	return comparisonTester(n, "<=", func(cmp int) bool { return cmp <= 0 })
+}
+
+func comparisonTester(n any, op string, ok func(int) bool) *NumericTester {
+	return &NumericTester{
+		expect: n,
+		op:     op,
+		bounds: []any{n},
+		check: func(actual reflect.Value) (bool, string) {
+			cmp, desc := compareNumbers(actual, reflect.ValueOf(n))
+			return desc == "" && ok(cmp), desc
+		},
+	}
+}
+
+func (nt *NumericTester) Test(c *C, actual any) []*Result {
+	for _, b := range nt.bounds {
+		if !isOrderedNumber(reflect.ValueOf(b)) {
+			return []*Result{c.FailUsage(fmt.Sprintf(
+				"The %s test requires real numbers but was given %s",
+				nt.op,
+				articleize(describeTypeOfActualValue(b)),
+			))}
+		}
+	}
+
+	if !isOrderedNumber(reflect.ValueOf(actual)) {
+		return []*Result{
+			c.FailType(actual, nt.expect, nt.op).WithDescription(fmt.Sprintf(
+				"Expected a real number but got %s",
+				articleize(describeTypeOfActualValue(actual)),
+			)),
+		}
+	}
+
+	pass, desc := nt.check(reflect.ValueOf(actual))
+	if pass {
+		return []*Result{c.Pass(actual, nt.expect, nt.op)}
+	}
+	return []*Result{c.FailValue(actual, nt.expect, nt.op).WithDescription(desc)}
+}
+
+// isOrderedNumber returns true for any numeric value which is not complex.
+func isOrderedNumber(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	info := isNumeric(v)
+	return info != nil && info.baseType != "complex"
+}
+
+// compareNumbers returns -1, 0, or 1 depending on whether the actual value is
+// less than, equal to, or greater than the expected value. If the two cannot
+// be compared then it returns a description of the problem.
+func compareNumbers(actual, expect reflect.Value) (int, string) {
+	if isNaN(actual) || isNaN(expect) {
+		return 0, "NaN cannot be compared to other numbers"
+	}
+
+	actualInfo := isNumeric(actual)
+	expectInfo := isNumeric(expect)
+
+	// Converting a negative int to a uint would wrap around, and converting a
+	// large uint to an int would overflow, so we compare ints and uints
+	// without converting them to each other's types.
+	if actualInfo.baseType == intBase && expectInfo.baseType == uintBase {
+		if actual.Int() < 0 {
+			return -1, ""
+		}
+		return compareOrdered(uint64(actual.Int()), expect.Uint()), ""
+	}
+	if actualInfo.baseType == uintBase && expectInfo.baseType == intBase {
+		if expect.Int() < 0 {
+			return 1, ""
+		}
+		return compareOrdered(actual.Uint(), uint64(expect.Int())), ""
+	}
+
+	a, e, desc := safelyConvertNumberTypes(actual, expect, actualInfo, expectInfo)
+	if desc != "" {
+		return 0, desc
+	}
+
+	// nolint: exhaustive
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), e.Int()), ""
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return compareOrdered(a.Uint(), e.Uint()), ""
+	}
+	return compareOrdered(a.Float(), e.Float()), ""
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// approxEqual returns true if the actual value is within epsilon of the
+// expected value. If both values are integers then the exact difference
+// between them is compared to epsilon, since converting large integers to
+// floats loses precision. Infinite values are only approximately equal to the
+// same infinity.
+func approxEqual(actual, expect, epsilon reflect.Value) (bool, string) {
+	epsilon = absNumber(epsilon)
+
+	if isInteger(actual) && isInteger(expect) {
+		if d, ok := intDistance(actual, expect); ok {
+			cmp, desc := compareNumbers(reflect.ValueOf(d), epsilon)
+			return desc == "" && cmp <= 0, desc
+		}
+	}
+
+	a := toFloat64(actual)
+	e := toFloat64(expect)
+
+	switch {
+	case math.IsNaN(a) || math.IsNaN(e):
+		return false, "NaN is not approximately equal to any number"
+	case math.IsInf(a, 0) || math.IsInf(e, 0):
+		if a == e {
+			return true, ""
+		}
+		return false, "An infinite value is only approximately equal to the same infinity"
+	}
+
+	return math.Abs(a-e) <= toFloat64(epsilon), ""
+}
+
+func isInteger(v reflect.Value) bool {
+	info := isNumeric(v)
+	return info != nil && (info.baseType == intBase || info.baseType == uintBase)
+}
+
+// intDistance returns the absolute difference between two integers, which may
+// be any mix of signed and unsigned types. The second return value is false if
+// the difference does not fit in a uint64.
+func intDistance(actual, expect reflect.Value) (uint64, bool) {
+	hi, lo := actual, expect
+	if cmp, _ := compareNumbers(actual, expect); cmp < 0 {
+		hi, lo = expect, actual
+	}
+
+	hiInfo := isNumeric(hi)
+	loInfo := isNumeric(lo)
+	switch {
+	case hiInfo.baseType == uintBase && loInfo.baseType == uintBase:
+		return hi.Uint() - lo.Uint(), true
+	case hiInfo.baseType == intBase && loInfo.baseType == intBase:
+		// The difference always fits in a uint64, so the wrap around from
+		// converting a negative int is cancelled out by the subtraction.
+		return uint64(hi.Int()) - uint64(lo.Int()), true
+	case loInfo.baseType == uintBase:
+		// hi is an int which is at least as big as lo, so it is not negative.
+		return uint64(hi.Int()) - lo.Uint(), true
+	}
+
+	// hi is a uint and lo is an int.
+	if lo.Int() >= 0 {
+		return hi.Uint() - uint64(lo.Int()), true
+	}
+	d := hi.Uint() + uint64(-lo.Int())
+	return d, d >= hi.Uint()
+}
+
+// absNumber returns the absolute value of a number. A negative int is
+// returned as a uint64 so that the absolute value of the smallest int does
+// not overflow.
+func absNumber(v reflect.Value) reflect.Value {
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return reflect.ValueOf(uint64(-v.Int()))
+		}
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(math.Abs(v.Float()))
+	}
+	return v
+}
+
+func isNaN(v reflect.Value) bool {
+	k := v.Kind()
+	return (k == reflect.Float32 || k == reflect.Float64) && math.IsNaN(v.Float())
+}
+
+func toFloat64(v reflect.Value) float64 {
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return math.NaN()
+}
@@ -0,0 +1,200 @@
+package contesta
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumericTesters(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	tests := map[string]contesterTest{
+		"Approx within epsilon": {
+			actual: 42.001,
+			expect: c.Approx(42, 0.01),
+			pass:   true,
+		},
+		"Approx outside epsilon": {
+			actual: 42.1,
+			expect: c.Approx(42, 0.01),
+			pass:   false,
+		},
+		"Approx with mixed types": {
+			actual: float32(1.5),
+			expect: c.Approx(int64(2), 1),
+			pass:   true,
+		},
+		"Approx NaN": {
+			actual: math.NaN(),
+			expect: c.Approx(math.NaN(), 1),
+			pass:   false,
+		},
+		"Approx same infinity": {
+			actual: math.Inf(1),
+			expect: c.Approx(math.Inf(1), 1),
+			pass:   true,
+		},
+		"Approx different infinity": {
+			actual: math.Inf(1),
+			expect: c.Approx(math.Inf(-1), 1),
+			pass:   false,
+		},
+		"Approx large ints": {
+			actual: int64(1 << 62),
+			expect: c.Approx(int64(1<<62+1), 0),
+			pass:   false,
+		},
+		"Approx large ints within epsilon": {
+			actual: int64(1 << 62),
+			expect: c.Approx(int64(1<<62+1), 1),
+			pass:   true,
+		},
+		"Approx large uint and negative int": {
+			actual: uint64(math.MaxUint64),
+			expect: c.Approx(int64(math.MinInt64), uint64(math.MaxUint64)),
+			pass:   false,
+		},
+		"Approx int with negative epsilon": {
+			actual: -3,
+			expect: c.Approx(uint8(2), -5),
+			pass:   true,
+		},
+		"Approx int with float epsilon": {
+			actual: 3,
+			expect: c.Approx(2, 0.5),
+			pass:   false,
+		},
+		"WithinRel large ints": {
+			actual: uint64(1 << 62),
+			expect: c.WithinRel(uint64(1<<62+1), 0),
+			pass:   false,
+		},
+		"WithinRel": {
+			actual: 209,
+			expect: c.WithinRel(200, 5),
+			pass:   true,
+		},
+		"WithinRel outside": {
+			actual: uint8(189),
+			expect: c.WithinRel(200, 5),
+			pass:   false,
+		},
+		"Between": {
+			actual: int16(5),
+			expect: c.Between(1, 10.5),
+			pass:   true,
+		},
+		"Between inclusive": {
+			actual: 10,
+			expect: c.Between(1, 10),
+			pass:   true,
+		},
+		"Between outside": {
+			actual: 11,
+			expect: c.Between(1, 10),
+			pass:   false,
+		},
+		"GreaterThan": {
+			actual: uint(5),
+			expect: c.GreaterThan(-1),
+			pass:   true,
+		},
+		"GreaterThan negative int vs uint": {
+			actual: int8(-1),
+			expect: c.GreaterThan(uint16(5)),
+			pass:   false,
+		},
+		"GreaterThan large uint": {
+			actual: int64(5),
+			expect: c.LessThan(uint64(math.MaxUint64)),
+			pass:   true,
+		},
+		"GreaterOrEqual": {
+			actual: 5,
+			expect: c.GreaterOrEqual(5.0),
+			pass:   true,
+		},
+		"LessThan": {
+			actual: 5,
+			expect: c.LessThan(5),
+			pass:   false,
+		},
+		"LessOrEqual": {
+			actual: 4.9,
+			expect: c.LessOrEqual(5),
+			pass:   true,
+		},
+		"LessThan NaN": {
+			actual: math.NaN(),
+			expect: c.LessThan(5),
+			pass:   false,
+		},
+		"not a number": {
+			actual: "5",
+			expect: c.LessThan(10),
+			pass:   false,
+		},
+		"complex bound": {
+			actual: 5,
+			expect: c.LessThan(complex(1, 1)),
+			pass:   false,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("Approx result", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(42.5, c.Approx(42, 0.1))
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, "≈ ±0.1", res[0].Op())
+			expect, _ := res[0].Expect()
+			assert.Equal(t, 42, expect)
+			actual, _ := res[0].Actual()
+			assert.Equal(t, 42.5, actual)
+		}
+	})
+
+	t.Run("tolerance in output", func(t *testing.T) {
+		for name, test := range map[string]struct {
+			actual any
+			expect func(c *C) Contester
+			line   string
+		}{
+			"Approx": {
+				actual: 42.5,
+				expect: func(c *C) Contester { return c.Approx(42, 0.1) },
+				line:   "got float64 42.5 expected ≈ ±0.1 int 42",
+			},
+			"WithinRel": {
+				actual: 189,
+				expect: func(c *C) Contester { return c.WithinRel(200, 5) },
+				line:   "got int 189 expected ≈ ±5% int 200",
+			},
+			"Between": {
+				actual: 11,
+				expect: func(c *C) Contester { return c.Between(1, 10) },
+				line:   "got int 11 expected between string [1, 10]",
+			},
+		} {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				lines := plainOutput(t, test.actual, test.expect)
+				if assert.Len(t, lines, 1) {
+					assert.Contains(t, lines[0], test.line)
+				}
+			})
+		}
+	})
+
+	t.Run("NaN description", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(math.NaN(), c.Approx(1, 1))
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, "NaN is not approximately equal to any number", res[0].Description())
+		}
+	})
+}
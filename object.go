@@ -0,0 +1,213 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ObjectTester tests a value by calling its methods.
+type ObjectTester struct {
+	tests  []ObjectTest
+	caller string
+}
+
+// ObjectTest is the interface for anything that can be passed to `c.Object`.
+type ObjectTest interface {
+	isObjectTest()
+	Test(c *C, value reflect.Value) []*Result
+}
+
+// Object takes one or more `ObjectTest` values and returns an
+// `*ObjectTester`. The tests are usually created by calling
+// `c.Call(method, args...).Is(expected...)`.
+func (c *C) Object(test ObjectTest, tests ...ObjectTest) *ObjectTester {
+	return &ObjectTester{
+		tests:  append([]ObjectTest{test}, tests...),
+		caller: c.Caller(),
+	}
+}
+
+func (ot *ObjectTester) Test(c *C, actual any) []*Result {
+	c.SetCaller(ot.caller)
+	defer c.UnsetCaller()
+
+	if actual == nil {
+		return []*Result{c.FailType(actual, nil, "").WithDescription(
+			"Cannot call methods on an untyped nil",
+		)}
+	}
+
+	va := reflect.ValueOf(actual)
+
+	var res []*Result
+	for _, t := range ot.tests {
+		res = append(res, t.Test(c, va)...)
+	}
+
+	return res
+}
+
+// IncompleteCallTest is returned by `c.Call`. Call its `Is` method to turn it
+// into a `CallTest`.
+type IncompleteCallTest struct {
+	method string
+	args   []any
+	c      *C
+}
+
+// CallTest calls a method and tests the values it returns.
+type CallTest struct {
+	method string
+	args   []any
+	expect []any
+	caller string
+}
+
+// Call returns an `IncompleteCallTest` for the named method, which will be
+// called with the given arguments. The method may have either a value or a
+// pointer receiver.
+func (c *C) Call(method string, args ...any) IncompleteCallTest {
+	return IncompleteCallTest{method, args, c}
+}
+
+// Is takes one expected value for each value that the method returns. Each
+// expected value can be either a literal value or a Contester. For a method
+// which returns `(T, error)`, you might write
+// `c.Call("Get", 42).Is("value", c.NoError())`.
+func (ict IncompleteCallTest) Is(expected ...any) CallTest {
+	return CallTest{
+		method: ict.method,
+		args:   ict.args,
+		expect: expected,
+		caller: ict.c.Caller(),
+	}
+}
+
+func (CallTest) isObjectTest() {}
+
+func (ct CallTest) Test(c *C, value reflect.Value) []*Result {
+	c.PushPath(Path{
+		data:   ct.pathData(),
+		callee: "contesta.IncompleteCallTest.Is",
+		caller: ct.caller,
+	})
+	defer c.PopPath()
+
+	m := methodByName(value, ct.method)
+	if !m.IsValid() {
+		return []*Result{c.FailStructure(fmt.Sprintf(
+			"The %s type has no method named %s",
+			describeType(value.Type()),
+			ct.method,
+		))}
+	}
+
+	args, desc := ct.argumentValues(m.Type())
+	if desc != "" {
+		return []*Result{c.FailUsage(desc)}
+	}
+
+	if m.Type().NumOut() != len(ct.expect) {
+		return []*Result{c.FailUsage(fmt.Sprintf(
+			"The %s method returns %s but %s given",
+			ct.method,
+			quantity(m.Type().NumOut(), "value", "values"),
+			countOf(len(ct.expect), "expected value", "expected values"),
+		))}
+	}
+
+	var out []reflect.Value
+	p := callAndRecover(func() { out = m.Call(args) })
+	if p.panicked {
+		return []*Result{{
+			pass:        false,
+			actual:      newValue(p.value),
+			paths:       c.Paths(),
+			where:       inValue,
+			description: fmt.Sprintf("Calling %s panicked:\n%s", ct.method, p.stack),
+		}}
+	}
+
+	switch len(out) {
+	case 0:
+		return []*Result{{
+			pass:        true,
+			description: fmt.Sprintf("%s was called", ct.method),
+			paths:       c.Paths(),
+		}}
+	case 1:
+		return c.is(out[0].Interface(), ct.expect[0])
+	}
+
+	var res []*Result
+	for i, o := range out {
+		c.PushPath(Path{data: fmt.Sprintf("return[%d]", i)})
+		res = append(res, c.is(o.Interface(), ct.expect[i])...)
+		c.PopPath()
+	}
+
+	return res
+}
+
+// pathData returns the data path element for the call, like `.Get(42)`.
+func (ct CallTest) pathData() string {
+	args := make([]string, 0, len(ct.args))
+	for _, a := range ct.args {
+		args = append(args, describeKey(reflect.ValueOf(a)))
+	}
+	return fmt.Sprintf(".%s(%s)", ct.method, strings.Join(args, ", "))
+}
+
+func (ct CallTest) argumentValues(ty reflect.Type) ([]reflect.Value, string) {
+	if (!ty.IsVariadic() && len(ct.args) != ty.NumIn()) ||
+		(ty.IsVariadic() && len(ct.args) < ty.NumIn()-1) {
+		return nil, fmt.Sprintf(
+			"The %s method takes %s but %s given",
+			ct.method,
+			quantity(ty.NumIn(), "argument", "arguments"),
+			countOf(len(ct.args), "argument", "arguments"),
+		)
+	}
+
+	args := make([]reflect.Value, 0, len(ct.args))
+	for i, a := range ct.args {
+		var in reflect.Type
+		if ty.IsVariadic() && i >= ty.NumIn()-1 {
+			in = ty.In(ty.NumIn() - 1).Elem()
+		} else {
+			in = ty.In(i)
+		}
+
+		v, ok := argumentValue(a, in)
+		if !ok {
+			return nil, fmt.Sprintf(
+				"Cannot pass %s as argument %d to the %s method, which expects %s",
+				articleize(describeTypeOfActualValue(a)),
+				i,
+				ct.method,
+				articleize(describeType(in)),
+			)
+		}
+		args = append(args, v)
+	}
+
+	return args, ""
+}
+
+// methodByName finds a method on the value. If the value is not a pointer and
+// the method has a pointer receiver, then the method is called on a pointer
+// to a copy of the value.
+func methodByName(value reflect.Value, name string) reflect.Value {
+	if m := value.MethodByName(name); m.IsValid() {
+		return m
+	}
+
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		return reflect.Value{}
+	}
+
+	p := reflect.New(value.Type())
+	p.Elem().Set(value)
+	return p.MethodByName(name)
+}
@@ -0,0 +1,168 @@
+package contesta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	id   int
+	name string
+}
+
+func (a account) ID() int {
+	return a.id
+}
+
+func (a *account) Name() string {
+	return a.name
+}
+
+func (a account) Lookup(key string) (string, error) {
+	if key == "name" {
+		return a.name, nil
+	}
+	return "", errors.New("no such key")
+}
+
+func (a account) Sum(base int, more ...int) int {
+	for _, m := range more {
+		base += m
+	}
+	return base
+}
+
+func (a *account) Touch() {
+	a.id++
+}
+
+func (a *account) Explode() {
+	panic("boom")
+}
+
+func TestObject(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	acct := account{id: 7, name: "x"}
+	tests := map[string]contesterTest{
+		"value receivers": {
+			actual: acct,
+			expect: c.Object(c.Call("ID").Is(7)),
+			pass:   true,
+		},
+		"pointer receiver on a value": {
+			actual: acct,
+			expect: c.Object(c.Call("ID").Is(7), c.Call("Name").Is("x")),
+			pass:   true,
+		},
+		"pointer": {
+			actual: &acct,
+			expect: c.Object(c.Call("ID").Is(7), c.Call("Name").Is("x")),
+			pass:   true,
+		},
+		"wrong value": {
+			actual: acct,
+			expect: c.Object(c.Call("ID").Is(8)),
+			pass:   false,
+		},
+		"multiple returns": {
+			actual: acct,
+			expect: c.Object(c.Call("Lookup", "name").Is("x", c.NoError())),
+			pass:   true,
+		},
+		"multiple returns with error": {
+			actual: acct,
+			expect: c.Object(c.Call("Lookup", "age").Is("", c.NoError())),
+			pass:   false,
+		},
+		"variadic": {
+			actual: acct,
+			expect: c.Object(c.Call("Sum", 1, 2, 3).Is(6)),
+			pass:   true,
+		},
+		"wrong number of expected values": {
+			actual: acct,
+			expect: c.Object(c.Call("Lookup", "name").Is("x")),
+			pass:   false,
+		},
+		"wrong argument type": {
+			actual: acct,
+			expect: c.Object(c.Call("Lookup", 42).Is("x", nil)),
+			pass:   false,
+		},
+		"no such method": {
+			actual: acct,
+			expect: c.Object(c.Call("Email").Is("x")),
+			pass:   false,
+		},
+		"no return values": {
+			actual: &account{},
+			expect: c.Object(c.Call("Touch").Is()),
+			pass:   true,
+		},
+		"no return values with panic": {
+			actual: &account{},
+			expect: c.Object(c.Call("Explode").Is()),
+			pass:   false,
+		},
+		"no return values with an expected value": {
+			actual: &account{},
+			expect: c.Object(c.Call("Touch").Is(nil)),
+			pass:   false,
+		},
+		"nil": {
+			actual: nil,
+			expect: c.Object(c.Call("ID").Is(7)),
+			pass:   false,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("no return values returns a result", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(&account{}, c.Object(c.Call("Touch").Is()))
+		if assert.Len(t, res, 1) {
+			assert.True(t, res[0].pass)
+			assert.Equal(t, "Touch was called", res[0].description)
+		}
+	})
+
+	t.Run("wrong number of values and arguments", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		for desc, test := range map[string]Contester{
+			"The ID method returns 1 value but 2 expected values were given": c.Object(
+				c.Call("ID").Is(1, 2),
+			),
+			"The Lookup method returns 2 values but 1 expected value was given": c.Object(
+				c.Call("Lookup", "name").Is("x"),
+			),
+			"The Lookup method takes 1 argument but 2 arguments were given": c.Object(
+				c.Call("Lookup", "a", "b").Is("x", nil),
+			),
+		} {
+			c.ResetState()
+			res := c.is(acct, test)
+			if assert.Len(t, res, 1, desc) {
+				assert.Equal(t, desc, res[0].Description())
+			}
+		}
+	})
+
+	t.Run("path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "account"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is(acct, c.Object(c.Call("Lookup", "name").Is("y", nil)))),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"account", `.Lookup("name")`, "return[0]"}},
+				{pass: true, dataPath: []string{"account", `.Lookup("name")`, "return[1]"}},
+			},
+			"Call",
+		)
+	})
+}
@@ -0,0 +1,126 @@
+package contesta
+
+import (
+	"runtime/debug"
+)
+
+// Panics tests that calling the function causes a panic.
+//
+// The final arguments follow the same rules as `c.Is`.
+func (c *C) Panics(f func(), args ...any) bool {
+	c.t.Helper()
+	c.ResetState()
+
+	c.PushPath(c.NewPath("func()", 0, "contesta.(*C).Panics"))
+	defer c.PopPath()
+
+	p := callAndRecover(f)
+	if !p.panicked {
+		return c.processResults([]*Result{c.didNotPanic()}, "Panics", args)
+	}
+
+	return c.processResults(
+		[]*Result{{pass: true, actual: newValue(p.value), paths: c.Paths()}},
+		"Panics",
+		args,
+	)
+}
+
+// NotPanics tests that calling the function does not cause a panic. If the
+// function does panic, the failure output includes the panic's stack trace.
+//
+// The final arguments follow the same rules as `c.Is`.
+func (c *C) NotPanics(f func(), args ...any) bool {
+	c.t.Helper()
+	c.ResetState()
+
+	c.PushPath(c.NewPath("func()", 0, "contesta.(*C).NotPanics"))
+	defer c.PopPath()
+
+	p := callAndRecover(f)
+	if !p.panicked {
+		return c.processResults(
+			[]*Result{{pass: true, paths: c.Paths(), description: "The function did not panic"}},
+			"NotPanics",
+			args,
+		)
+	}
+
+	return c.processResults(
+		[]*Result{{
+			pass:        false,
+			actual:      newValue(p.value),
+			paths:       c.Paths(),
+			where:       inValue,
+			description: "The function panicked unexpectedly:\n" + p.stack,
+		}},
+		"NotPanics",
+		args,
+	)
+}
+
+// PanicsWith tests that calling the function causes a panic, and that the
+// value passed to `panic` matches the expected value. The `expected` argument
+// can be either a literal value or anything that implements the
+// `contesta.Contester` interface. If the value does not match, the failure
+// output includes the panic's stack trace.
+//
+// The final arguments follow the same rules as `c.Is`.
+func (c *C) PanicsWith(f func(), expected any, args ...any) bool {
+	c.t.Helper()
+	c.ResetState()
+
+	c.PushPath(c.NewPath("func()", 0, "contesta.(*C).PanicsWith"))
+	defer c.PopPath()
+
+	p := callAndRecover(f)
+	if !p.panicked {
+		return c.processResults([]*Result{c.didNotPanic()}, "PanicsWith", args)
+	}
+
+	c.PushPath(Path{data: "recover()"})
+	defer c.PopPath()
+
+	results := c.is(p.value, expected)
+	for _, r := range results {
+		if !r.pass && r.description == "" {
+			r.description = "The function panicked with an unexpected value:\n" + p.stack
+		}
+	}
+
+	return c.processResults(results, "PanicsWith", args)
+}
+
+func (c *C) didNotPanic() *Result {
+	return &Result{
+		pass:        false,
+		paths:       c.Paths(),
+		where:       inValue,
+		description: "The function did not panic",
+	}
+}
+
+type recovered struct {
+	panicked bool
+	value    any
+	stack    string
+}
+
+// callAndRecover calls the function and returns information about the panic,
+// if one occurred. A function which calls `panic(nil)` is treated as
+// panicking, even on versions of Go where `recover()` returns nil for this.
+func callAndRecover(f func()) recovered {
+	r := recovered{panicked: true}
+	func() {
+		defer func() {
+			if r.panicked {
+				r.value = recover()
+				r.stack = string(debug.Stack())
+			}
+		}()
+		f()
+		r.panicked = false
+	}()
+
+	return r
+}
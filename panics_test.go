@@ -0,0 +1,58 @@
+package contesta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanics(t *testing.T) {
+	t.Run("Panics", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Panics(func() { panic("oops") })
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.Panics(func() {})
+		m.AssertFailed(t)
+	})
+	t.Run("NotPanics", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.NotPanics(func() {})
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.NotPanics(func() { panic("oops") })
+		m.AssertFailed(t)
+		assert.Contains(t, m.output(), "The function panicked unexpectedly:")
+		assert.Contains(t, m.output(), "goroutine ")
+	})
+	t.Run("PanicsWith", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.PanicsWith(func() { panic("oops") }, "oops")
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.PanicsWith(func() { panic(errors.New("bad index")) }, c.ErrorMatches(`index`))
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.PanicsWith(func() { panic("oops") }, "uh oh")
+		m.AssertFailed(t)
+		assert.Contains(t, m.output(), "The function panicked with an unexpected value:")
+		assert.Contains(t, m.output(), "goroutine ")
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.PanicsWith(func() {}, "oops")
+		m.AssertFailed(t)
+	})
+}
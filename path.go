@@ -17,6 +17,11 @@ type Path struct {
 	caller string
 }
 
+// Data returns the data path element, like `["foo"]` or `.Name`.
+func (p Path) Data() string {
+	return p.data
+}
+
 var ourPackages = map[string]bool{}
 var stdlibRoot string
 
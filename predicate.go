@@ -0,0 +1,73 @@
+package contesta
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// PredicateTester tests a value with a function. The actual value must be of
+// type `T`.
+type PredicateTester[T any] struct {
+	fn          func(T) error
+	description string
+}
+
+// Satisfies returns a `*PredicateTester` which passes if the function returns
+// true for the actual value. The description is shown as the expected value
+// when the test fails, so it should describe what the function checks, like
+// "an even number".
+//
+// This is a function rather than a method on `*C` because Go methods cannot
+// have type parameters.
+func Satisfies[T any](fn func(T) bool, description string) *PredicateTester[T] {
+	return &PredicateTester[T]{
+		fn: func(v T) error {
+			if fn(v) {
+				return nil
+			}
+			return errors.New("the predicate returned false")
+		},
+		description: description,
+	}
+}
+
+// SatisfiesErr is like `Satisfies` but the function returns an error instead
+// of a bool. The test passes if the error is nil. Otherwise the error's
+// message is shown below the failure table.
+func SatisfiesErr[T any](fn func(T) error, description string) *PredicateTester[T] {
+	return &PredicateTester[T]{
+		fn:          fn,
+		description: description,
+	}
+}
+
+func (pt *PredicateTester[T]) Test(c *C, actual any) []*Result {
+	ty := reflect.TypeOf((*T)(nil)).Elem()
+
+	v, ok := actual.(T)
+	if !ok {
+		if actual != nil || !isNilable(ty.Kind()) {
+			res := c.FailType(actual, pt.description, "satisfies").WithDescription(fmt.Sprintf(
+				"Expected %s but got %s",
+				articleize(describeType(ty)),
+				articleize(describeTypeOfActualValue(actual)),
+			))
+			res.expect.desc = describeType(ty)
+			return []*Result{res}
+		}
+		// An untyped nil is a valid value for any nilable type.
+		var zero T
+		v = zero
+	}
+
+	var res *Result
+	if err := pt.fn(v); err != nil {
+		res = c.FailValue(actual, pt.description, "satisfies").WithDescription(err.Error())
+	} else {
+		res = c.Pass(actual, pt.description, "satisfies")
+	}
+	res.expect.desc = describeType(ty)
+
+	return []*Result{res}
+}
@@ -0,0 +1,52 @@
+package contesta
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSatisfies(t *testing.T) {
+	isEven := Satisfies(func(i int) bool { return i%2 == 0 }, "an even number")
+	tests := map[string]contesterTest{
+		"predicate returns true": {
+			actual: 42,
+			expect: isEven,
+			pass:   true,
+		},
+		"predicate returns false": {
+			actual: 43,
+			expect: isEven,
+			pass:   false,
+		},
+		"wrong type": {
+			actual: int64(42),
+			expect: isEven,
+			pass:   false,
+		},
+		"nil for a pointer type": {
+			actual: nil,
+			expect: Satisfies(func(p *int) bool { return p == nil }, "a nil pointer"),
+			pass:   true,
+		},
+		"nil for a non-nilable type": {
+			actual: nil,
+			expect: isEven,
+			pass:   false,
+		},
+		"SatisfiesErr returns nil": {
+			actual: "ok",
+			expect: SatisfiesErr(func(string) error { return nil }, "anything"),
+			pass:   true,
+		},
+		"SatisfiesErr returns an error": {
+			actual: "ok",
+			expect: SatisfiesErr(
+				func(string) error { return errors.New("not ok") },
+				"nothing",
+			),
+			pass: false,
+		},
+	}
+
+	runContesterTests(t, tests)
+}
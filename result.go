@@ -21,7 +21,14 @@ type value struct {
 	desc  string
 }
 
-type result struct {
+// Result is the outcome of a single check made by a `Contester`. A `Result`
+// records whether the check passed, the values that were compared, and the
+// data path at which the check was made.
+//
+// Results should be created with the constructor methods on `*C`, like
+// `c.Pass` and `c.FailValue`, which attach the current data path to the
+// result.
+type Result struct {
 	pass        bool
 	actual      *value
 	expect      *value
@@ -30,6 +37,10 @@ type result struct {
 	caller      *string
 	where       failure
 	description string
+	// mismatch is true for a type failure from comparing two values for
+	// equality. The values are not equal, but unlike other type failures the
+	// test could still be applied, so `Not` inverts it.
+	mismatch bool
 }
 
 type failure int
@@ -45,25 +56,130 @@ func newValue(val any) *value {
 	return &value{value: val}
 }
 
-func (r result) hasPaths() bool {
+// Pass returns a passing `*Result` for the given values and operator.
+func (c *C) Pass(actual, expect any, op string) *Result {
+	return &Result{
+		pass:   true,
+		actual: newValue(actual),
+		expect: newValue(expect),
+		op:     op,
+		paths:  c.Paths(),
+	}
+}
+
+// FailValue returns a failing `*Result` for two values which are comparable
+// but which did not match, for example 42 and 43.
+func (c *C) FailValue(actual, expect any, op string) *Result {
+	return &Result{
+		pass:   false,
+		actual: newValue(actual),
+		expect: newValue(expect),
+		op:     op,
+		paths:  c.Paths(),
+		where:  inValue,
+	}
+}
+
+// FailType returns a failing `*Result` for two values which could not be
+// compared because their types are not compatible.
+func (c *C) FailType(actual, expect any, op string) *Result {
+	return &Result{
+		pass:   false,
+		actual: newValue(actual),
+		expect: newValue(expect),
+		op:     op,
+		paths:  c.Paths(),
+		where:  inType,
+	}
+}
+
+// FailStructure returns a failing `*Result` for a problem with the shape of a
+// data structure, such as a missing map key. The description is shown below
+// the failure table.
+func (c *C) FailStructure(desc string) *Result {
+	return &Result{
+		pass:        false,
+		paths:       c.Paths(),
+		where:       inDataStructure,
+		description: desc,
+	}
+}
+
+// FailUsage returns a failing `*Result` for a test which was used
+// incorrectly, for example a tester which was given a value that it does not
+// support.
+func (c *C) FailUsage(desc string) *Result {
+	return &Result{
+		pass:        false,
+		paths:       c.Paths(),
+		where:       inUsage,
+		description: desc,
+	}
+}
+
+// WithDescription sets the result's description and returns the result.
+func (r *Result) WithDescription(desc string) *Result {
+	r.description = desc
+	return r
+}
+
+// Passed returns true if the result is a pass.
+func (r *Result) Passed() bool {
+	return r.pass
+}
+
+// Actual returns the actual value for the result. The second return value is
+// false if the result has no actual value.
+func (r *Result) Actual() (any, bool) {
+	if r.actual == nil {
+		return nil, false
+	}
+	return r.actual.value, true
+}
+
+// Expect returns the expected value for the result. The second return value
+// is false if the result has no expected value.
+func (r *Result) Expect() (any, bool) {
+	if r.expect == nil {
+		return nil, false
+	}
+	return r.expect.value, true
+}
+
+// Op returns the operator for the result, like "==".
+func (r *Result) Op() string {
+	return r.op
+}
+
+// Paths returns the data path at which the result was created.
+func (r *Result) Paths() []Path {
+	return r.paths
+}
+
+// Description returns the result's description, if it has one.
+func (r *Result) Description() string {
+	return r.description
+}
+
+func (r Result) hasPaths() bool {
 	return len(r.paths) != 0
 }
 
-func (r result) showActual() bool {
+func (r Result) showActual() bool {
 	return r.actual != nil
 }
 
-func (r result) showExpect() bool {
+func (r Result) showExpect() bool {
 	return r.expect != nil
 }
 
 type describer struct {
-	r  result
+	r  Result
 	tw table.Writer
 	s  ansi.Scheme
 }
 
-func (r result) describe(name string, s ansi.Scheme) string {
+func (r Result) describe(name string, s ansi.Scheme) string {
 	if r.pass {
 		return s.Correct(s.Strong(fmt.Sprintf("Assertion ok: %s", name))) + "\n\n"
 	}
@@ -189,7 +305,9 @@ func (d describer) footer() ([]any, map[string]int) {
 		actual = d.s.Incorrect(actual)
 		expect = d.s.Correct(expect)
 	case inDataStructure:
-		op = d.s.Incorrect(op)
+		if op != "" {
+			op = d.s.Incorrect(op)
+		}
 	}
 
 	footer := table.Row{}
@@ -215,16 +333,26 @@ func (d describer) footer() ([]any, map[string]int) {
 	return footer, widths
 }
 
+// description returns the value's type for the table's type column. A nil
+// value is shown as "<nil>", since the table shows no value for it.
 func (v *value) description() string {
 	if v.desc != "" {
 		return v.desc
 	}
 
-	v.desc = describeTypeOfActualValue(v.value)
+	desc := v.typeName()
 	if v.value == nil {
-		v.desc += " <nil>"
+		desc += " <nil>"
+	}
+	return desc
+}
+
+// typeName returns the value's type, or the description set for it.
+func (v *value) typeName() string {
+	if v.desc != "" {
+		return v.desc
 	}
-	return v.desc
+	return describeTypeOfActualValue(v.value)
 }
 
 func describeTypeOfActualValue(val any) string {
@@ -262,7 +390,8 @@ func describeType(ty reflect.Type) string {
 	case reflect.Func:
 		return describeFunc(ty)
 	case reflect.Interface:
-		// Can this happen?
+		// This is an unnamed interface, like the `any` in a `map[any]int`.
+		return ty.String()
 	case reflect.Map:
 		return fmt.Sprintf("map[%s]%s", describeType(ty.Key()), describeType(ty.Elem()))
 	case reflect.Ptr:
@@ -36,3 +36,43 @@ func Test_typeOf(t *testing.T) {
 
 	assert.Equal(t, "nil", describeTypeOfActualValue(nil))
 }
+
+type evenTester struct{}
+
+func (evenTester) Test(c *C, actual any) []*Result {
+	i, ok := actual.(int)
+	if !ok {
+		return []*Result{c.FailType(actual, 0, "is even")}
+	}
+	if i%2 != 0 {
+		return []*Result{c.FailValue(actual, "an even number", "is")}
+	}
+	return []*Result{c.Pass(actual, "an even number", "is")}
+}
+
+func TestResultConstructors(t *testing.T) {
+	t.Run("custom Contester", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]int{"foo": 2}, c.Map(c.Key("foo").Is(evenTester{})))
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.Is(map[string]int{"foo": 3}, c.Map(c.Key("foo").Is(evenTester{})))
+		m.AssertFailed(t)
+	})
+	t.Run("paths are attached", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "int"})
+		r := c.FailStructure("broken")
+		assert.False(t, r.Passed())
+		assert.Equal(t, "broken", r.Description())
+		if assert.Len(t, r.Paths(), 1) {
+			assert.Equal(t, "int", r.Paths()[0].Data())
+		}
+		_, ok := r.Actual()
+		assert.False(t, ok, "structure failure has no actual value")
+	})
+}
@@ -0,0 +1,124 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LenTester tests the length of a string, slice, array, map, or channel.
+type LenTester struct {
+	expect any
+}
+
+// Len returns a `*LenTester` which passes if the actual value's length matches
+// `n`. The `n` argument can be either a number or a Contester, such as
+// `c.GreaterThan(2)`. A literal number is compared using the same rules as
+// `c.ValueIs`, so it does not have to be an `int`.
+func (c *C) Len(n any) *LenTester {
+	return &LenTester{expect: n}
+}
+
+func (lt *LenTester) Test(c *C, actual any) []*Result {
+	v, res := c.lengthOf(actual, lt.expect, "len() ==")
+	if res != nil {
+		return []*Result{res}
+	}
+
+	c.PushPath(Path{data: "len()"})
+	defer c.PopPath()
+
+	if _, ok := lt.expect.(Contester); ok {
+		return c.is(v, lt.expect)
+	}
+	return ValueEqualityTester{lt.expect}.Test(c, v)
+}
+
+// EmptyTester tests whether a string, slice, array, map, or channel has a
+// length of zero.
+type EmptyTester struct {
+	empty bool
+}
+
+// Empty returns an `*EmptyTester` which passes if the actual value has a
+// length of zero. A nil slice, map, or channel is empty.
+func (c *C) Empty() *EmptyTester {
+	return &EmptyTester{empty: true}
+}
+
+// NotEmpty returns an `*EmptyTester` which passes if the actual value has a
+// length greater than zero.
+func (c *C) NotEmpty() *EmptyTester {
+	return &EmptyTester{empty: false}
+}
+
+func (et *EmptyTester) Test(c *C, actual any) []*Result {
+	op := "len() =="
+	if !et.empty {
+		op = "len() !="
+	}
+
+	l, res := c.lengthOf(actual, 0, op)
+	if res != nil {
+		return []*Result{res}
+	}
+
+	if (l == 0) == et.empty {
+		return []*Result{c.Pass(actual, 0, op)}
+	}
+	return []*Result{c.FailValue(actual, 0, op).WithDescription(
+		fmt.Sprintf("The length is %d", l),
+	)}
+}
+
+// NilTester tests whether a value is nil. A typed nil, such as a nil `*T`
+// stored in an `any`, is treated as nil, just as it is by `c.Is`.
+type NilTester struct {
+	isNil bool
+}
+
+// Nil returns a `*NilTester` which passes if the actual value is nil.
+func (c *C) Nil() *NilTester {
+	return &NilTester{isNil: true}
+}
+
+// NotNil returns a `*NilTester` which passes if the actual value is not nil.
+func (c *C) NotNil() *NilTester {
+	return &NilTester{isNil: false}
+}
+
+func (nt *NilTester) Test(c *C, actual any) []*Result {
+	op := "=="
+	if !nt.isNil {
+		op = "!="
+	}
+
+	if nilValuesAreEqual(actual, nil) == nt.isNil {
+		return []*Result{c.Pass(actual, nil, op)}
+	}
+
+	res := c.FailValue(actual, nil, op)
+	if v := reflect.ValueOf(actual); nt.isNil && !isNilable(v.Kind()) {
+		res = res.WithDescription(fmt.Sprintf(
+			"%s can never be nil",
+			articleize(describeTypeOfActualValue(actual)),
+		))
+	}
+	return []*Result{res}
+}
+
+// lengthOf returns the length of the actual value. If the value does not have
+// a length then this returns a failed result instead.
+func (c *C) lengthOf(actual, expect any, op string) (int, *Result) {
+	v := reflect.ValueOf(actual)
+
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), nil
+	}
+
+	return 0, c.FailType(actual, expect, op).WithDescription(fmt.Sprintf(
+		"Expected a string, slice, array, map, or channel but got %s",
+		articleize(describeTypeOfActualValue(actual)),
+	))
+}
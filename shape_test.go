@@ -0,0 +1,64 @@
+package contesta
+
+import (
+	"testing"
+)
+
+func TestShape(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	var nilSlice []int
+	var nilPtr *int
+	var nilPtrInInterface any = nilPtr
+	ch := make(chan int, 2)
+	ch <- 1
+
+	tests := map[string]contesterTest{
+		"Len of slice":                 {[]int{1, 2, 3}, c.Len(3), true},
+		"Len of slice is wrong":        {[]int{1, 2, 3}, c.Len(2), false},
+		"Len with int64":               {[]int{1, 2, 3}, c.Len(int64(3)), true},
+		"Len of string":                {"abc", c.Len(3), true},
+		"Len of array":                 {[2]int{1, 2}, c.Len(2), true},
+		"Len of map":                   {map[string]int{"a": 1}, c.Len(1), true},
+		"Len of channel":               {ch, c.Len(1), true},
+		"Len of nil slice":             {nilSlice, c.Len(0), true},
+		"Len with Contester":           {[]int{1, 2, 3}, c.Len(c.GreaterThan(2)), true},
+		"Len with failing Contester":   {[]int{1, 2, 3}, c.Len(c.LessThan(2)), false},
+		"Len of int":                   {42, c.Len(1), false},
+		"Len of nil":                   {nil, c.Len(0), false},
+		"Len with non-number":          {[]int{1}, c.Len("1"), false},
+		"Empty slice":                  {[]int{}, c.Empty(), true},
+		"Empty nil slice":              {nilSlice, c.Empty(), true},
+		"Empty string":                 {"", c.Empty(), true},
+		"Empty with non-empty map":     {map[string]int{"a": 1}, c.Empty(), false},
+		"Empty with int":               {0, c.Empty(), false},
+		"NotEmpty slice":               {[]int{1}, c.NotEmpty(), true},
+		"NotEmpty with empty string":   {"", c.NotEmpty(), false},
+		"Nil with untyped nil":         {nil, c.Nil(), true},
+		"Nil with nil slice":           {nilSlice, c.Nil(), true},
+		"Nil with typed nil":           {nilPtrInInterface, c.Nil(), true},
+		"Nil with non-nil pointer":     {new(int), c.Nil(), false},
+		"Nil with int":                 {0, c.Nil(), false},
+		"NotNil with non-nil pointer":  {new(int), c.NotNil(), true},
+		"NotNil with typed nil":        {nilPtrInInterface, c.NotNil(), false},
+		"NotNil with untyped nil":      {nil, c.NotNil(), false},
+		"NotNil with int":              {0, c.NotNil(), true},
+		"NotNil with empty slice":      {[]int{}, c.NotNil(), true},
+		"Nil with empty map":           {map[string]int{}, c.Nil(), false},
+		"Nested Len in Map":            {map[string][]int{"a": {1}}, c.Map(c.Key("a").Is(c.Len(1))), true},
+		"Nested NotEmpty in Map fails": {map[string][]int{"a": {}}, c.Map(c.Key("a").Is(c.NotEmpty())), false},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("Len path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "[]int"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is([]int{1, 2}, c.Len(3))),
+			[]resultExpect{{pass: false, dataPath: []string{"[]int", "len()"}}},
+			"Len",
+		)
+	})
+}
@@ -131,6 +131,54 @@ func (mt *mockT) WriteString(s string) (int, error) {
 	return len([]byte(s)), nil
 }
 
+// output returns everything that was written with `WriteString`.
+func (mt *mockT) output() string {
+	var out strings.Builder
+	for _, c := range mt.calls {
+		if c.Method == "WriteString" {
+			out.WriteString(c.Args[0].(string))
+		}
+	}
+	return out.String()
+}
+
+// contesterTest is a test case for a Contester. The actual value is tested
+// against the Contester with `c.Is`, which should pass if `pass` is true.
+type contesterTest struct {
+	actual any
+	expect Contester
+	pass   bool
+}
+
+// runContesterTests runs each test case as a subtest, with its own `*C`.
+func runContesterTests(t *testing.T, tests map[string]contesterTest) {
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(test.actual, test.expect)
+			if test.pass {
+				m.AssertPassed(t)
+			} else {
+				m.AssertFailed(t)
+			}
+		})
+	}
+}
+
+// plainOutput runs `c.Is` with the plain formatter and returns the lines of
+// output, so that tests can check how failures are rendered.
+func plainOutput(t *testing.T, actual any, expect func(c *C) Contester) []string {
+	t.Setenv("CONTESTA_COLOR", "never")
+
+	m := newMockT()
+	c := NewWithOutput(m, m)
+	c.SetFormatter(NewPlainFormatter())
+	c.Is(actual, expect(c))
+	return strings.Split(strings.TrimSuffix(m.output(), "\n"), "\n")
+}
+
 type resultExpect struct {
 	pass     bool
 	dataPath []string
@@ -152,6 +200,14 @@ func AssertResultsAre(t *testing.T, actual []outputItem, expect []resultExpect,
 	}
 }
 
+func outputItems(results []*Result) []outputItem {
+	items := make([]outputItem, 0, len(results))
+	for _, r := range results {
+		items = append(items, outputItem{result: r})
+	}
+	return items
+}
+
 // type GTComparer int
 
 // func (sc GTComparer) Compare(c *C) {
@@ -1,31 +1,164 @@
 package contesta
 
-// type SliceTester struct {
-// 	tests []SliceTest
-// }
-
-// type SliceTest struct {
-// 	index int
-// 	test  any
-// }
-
-// type IncompleteSliceTest struct {
-// 	index  int
-// 	isNext bool
-// }
-
-// func Slice(test SliceTest, tests ...SliceTest) SliceTester {
-// 	return SliceTester{append([]SliceTest{test}, tests...)}
-// }
-
-// func Next() IncompleteSliceTest {
-// 	return IncompleteSliceTest{isNext: true}
-// }
-
-// func (ist IncompleteSliceTest) Is(test any) SliceTest {
-// 	return SliceTest{ist.index, test}
-// }
-
-// func (st SliceTest) Test(c *C, value any) Failure {
-// 	return c.is(value, st.test)
-// }
+import (
+	"fmt"
+	"reflect"
+)
+
+// SliceTester tests the elements of a slice or an array.
+type SliceTester struct {
+	tests  []SliceTest
+	caller string
+}
+
+// SliceTest is the interface for anything that can be passed to `c.Slice`.
+type SliceTest interface {
+	isSliceTest()
+	Test(c *C, value reflect.Value) []*Result
+}
+
+// Slice takes one or more `SliceTest` values and returns a `*SliceTester`. The
+// tests are usually created by calling `c.Next()` or `c.Idx(n)`. The returned
+// tester works on both slices and arrays.
+//
+// Each `c.Next()` test applies to the element after the one tested by the
+// previous element test, starting at index 0.
+//
+// As with `c.Map`, `c.End()` is added if neither `c.End()` nor
+// `c.NonExhaustive()` is given.
+func (c *C) Slice(test SliceTest, tests ...SliceTest) *SliceTester {
+	tests = append([]SliceTest{test}, tests...)
+
+	var ordered, terminators []SliceTest
+	next := 0
+	for _, t := range tests {
+		if isContainerTerminator(t) {
+			terminators = append(terminators, t)
+			continue
+		}
+		if typed, ok := t.(interface{ sliceElementTest() SliceElementTest }); ok {
+			t = typed.sliceElementTest()
+		}
+		if st, ok := t.(SliceElementTest); ok {
+			if st.isNext {
+				st.index = next
+				t = st
+			}
+			next = st.index + 1
+		}
+		ordered = append(ordered, t)
+	}
+	if len(terminators) == 0 {
+		terminators = append(terminators, End())
+	}
+
+	return &SliceTester{
+		tests:  append(ordered, terminators...),
+		caller: c.Caller(),
+	}
+}
+
+func (st *SliceTester) Test(c *C, actual any) []*Result {
+	c.SetCaller(st.caller)
+	defer c.UnsetCaller()
+
+	vt := reflect.TypeOf(actual)
+	if vt == nil || (vt.Kind() != reflect.Slice && vt.Kind() != reflect.Array) {
+		return unexpectedKindResult(c, "a slice or array", vt)
+	}
+
+	va := reflect.ValueOf(actual)
+
+	c.pushContainer()
+	defer c.popContainer()
+
+	var res []*Result
+	for _, t := range st.tests {
+		res = append(res, t.Test(c, va)...)
+	}
+
+	return res
+}
+
+// IncompleteSliceTest is returned by `c.Next()` and `c.Idx(n)`. Call its `Is`
+// method to turn it into a `SliceElementTest`.
+type IncompleteSliceTest struct {
+	index  int
+	isNext bool
+	c      *C
+}
+
+// SliceElementTest tests a single element of a slice or array.
+type SliceElementTest struct {
+	index  int
+	isNext bool
+	test   any
+	caller string
+	// callee overrides the callee shown for the element's path element. This
+	// is used by `NextOf` and `IdxOf`.
+	callee string
+}
+
+// Next returns an `IncompleteSliceTest` for the element following the one
+// tested by the previous element test.
+func (c *C) Next() IncompleteSliceTest {
+	return IncompleteSliceTest{isNext: true, c: c}
+}
+
+// Idx returns an `IncompleteSliceTest` for the element at the given index.
+func (c *C) Idx(index int) IncompleteSliceTest {
+	return IncompleteSliceTest{index: index, c: c}
+}
+
+func (ist IncompleteSliceTest) Is(expected any) SliceElementTest {
+	return SliceElementTest{
+		index:  ist.index,
+		isNext: ist.isNext,
+		test:   expected,
+		caller: ist.c.Caller(),
+	}
+}
+
+func (SliceElementTest) isSliceTest() {}
+
+func (st SliceElementTest) Test(c *C, value reflect.Value) []*Result {
+	callee := st.callee
+	if callee == "" {
+		callee = "contesta.IncompleteSliceTest.Is"
+	}
+	c.PushPath(Path{
+		data:   fmt.Sprintf("[%d]", st.index),
+		callee: callee,
+		caller: st.caller,
+	})
+	defer c.PopPath()
+
+	if st.index < 0 || st.index >= value.Len() {
+		return []*Result{{
+			pass: false,
+			description: fmt.Sprintf(
+				"Index %d does not exist in %s with %s",
+				st.index,
+				articleize(describeType(value.Type())),
+				quantity(value.Len(), "element", "elements"),
+			),
+			paths: c.Paths(),
+			where: inDataStructure,
+		}}
+	}
+
+	c.markChecked(st.index)
+	return c.is(value.Index(st.index).Interface(), st.test)
+}
+
+type SliceElementsNotCheckedFailure struct {
+	indexes []int
+}
+
+func (senf SliceElementsNotCheckedFailure) Failure() string {
+	return fmt.Sprintf(
+		"%s not checked: %v",
+		countOf(len(senf.indexes), "element in the slice", "elements in the slice"),
+		senf.indexes,
+	)
+}
@@ -0,0 +1,101 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlice(t *testing.T) {
+	t.Run("Next", func(t *testing.T) {
+		t.Run("all elements match", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is([]string{"hi", "ho"}, c.Slice(c.Next().Is("hi"), c.Next().Is("ho")))
+			m.AssertPassed(t)
+		})
+		t.Run("second element does not match", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is([]string{"hi", "ho"}, c.Slice(c.Next().Is("hi"), c.Next().Is("hey")))
+			m.AssertFailed(t)
+		})
+	})
+	t.Run("Idx", func(t *testing.T) {
+		t.Run("Next after Idx", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is([]int{1, 2, 3, 4}, c.Slice(c.Idx(2).Is(3), c.Next().Is(4), NonExhaustive()))
+			m.AssertPassed(t)
+		})
+		t.Run("index out of range", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is([]int{1}, c.Slice(c.Idx(3).Is(1)))
+			m.AssertFailed(t)
+
+			c.ResetState()
+			res := c.is([]int{1}, c.Slice(c.Idx(3).Is(1), NonExhaustive()))
+			if assert.Len(t, res, 2) {
+				assert.Equal(t, "Index 3 does not exist in a []int with 1 element", res[0].Description())
+			}
+		})
+	})
+	t.Run("arrays", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is([2]int{1, 2}, c.Slice(c.Next().Is(1), c.Next().Is(2)))
+		m.AssertPassed(t)
+	})
+	t.Run("not a slice", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(42, c.Slice(c.Next().Is(42)))
+		m.AssertFailed(t)
+	})
+	t.Run("nested path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "[][]int"})
+		res := c.is(
+			[][]int{{1}, {2, 3}},
+			c.Slice(
+				c.Next().Is(c.Slice(c.Next().Is(1))),
+				c.Next().Is(c.Slice(c.Idx(1).Is(4), NonExhaustive())),
+			),
+		)
+		AssertResultsAre(
+			t,
+			outputItems(res),
+			[]resultExpect{
+				{pass: true, dataPath: []string{"[][]int", "[0]", "[0]"}},
+				{pass: true, dataPath: []string{"[][]int", "[0]"}},
+				{pass: false, dataPath: []string{"[][]int", "[1]", "[1]"}},
+				{pass: true, dataPath: []string{"[][]int", "[1]"}},
+				{pass: true, dataPath: []string{"[][]int"}},
+			},
+			"nested slices",
+		)
+	})
+}
+
+func TestSliceEnd(t *testing.T) {
+	t.Run("unchecked slice elements", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is([]int{1, 2, 3}, c.Slice(c.Next().Is(1)))
+		m.AssertFailed(t)
+
+		c.ResetState()
+		res := c.is([]int{1, 2}, c.Slice(c.Next().Is(1)))
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, "1 element in the slice was not checked: [1]", res[1].description)
+		}
+	})
+	t.Run("unchecked slice elements with NonExhaustive", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is([]int{1, 2, 3}, c.Slice(c.Next().Is(1), NonExhaustive()))
+		m.AssertPassed(t)
+	})
+}
@@ -0,0 +1,177 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// StringTester tests a string-like value. It works with strings, byte slices,
+// and anything that implements `fmt.Stringer`, as well as types whose
+// underlying type is a string or byte slice.
+type StringTester struct {
+	expect any
+	op     string
+	match  func(string) bool
+	err    error
+}
+
+// Like returns a `*StringTester` which passes if the actual value matches the
+// given regular expression. The `re` argument can be either a `string` or a
+// `*regexp.Regexp`.
+func (c *C) Like(re any) *StringTester {
+	r, err := toRegexp(re)
+	if err != nil {
+		return &StringTester{expect: re, op: "=~", err: err}
+	}
+	return &StringTester{expect: r, op: "=~", match: r.MatchString}
+}
+
+// Unlike returns a `*StringTester` which passes if the actual value does not
+// match the given regular expression. The `re` argument can be either a
+// `string` or a `*regexp.Regexp`.
+func (c *C) Unlike(re any) *StringTester {
+	r, err := toRegexp(re)
+	if err != nil {
+		return &StringTester{expect: re, op: "!~", err: err}
+	}
+	return &StringTester{
+		expect: r,
+		op:     "!~",
+		match:  func(s string) bool { return !r.MatchString(s) },
+	}
+}
+
+// HasPrefix returns a `*StringTester` which passes if the actual value starts
+// with the given prefix.
+func (c *C) HasPrefix(prefix string) *StringTester {
+	return &StringTester{
+		expect: prefix,
+		op:     "has prefix",
+		match:  func(s string) bool { return strings.HasPrefix(s, prefix) },
+	}
+}
+
+// HasPrefixFold is like `HasPrefix` but compares the strings
+// case-insensitively.
+func (c *C) HasPrefixFold(prefix string) *StringTester {
+	return &StringTester{
+		expect: prefix,
+		op:     "has prefix (case-insensitive)",
+		match: func(s string) bool {
+			return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+		},
+	}
+}
+
+// HasSuffix returns a `*StringTester` which passes if the actual value ends
+// with the given suffix.
+func (c *C) HasSuffix(suffix string) *StringTester {
+	return &StringTester{
+		expect: suffix,
+		op:     "has suffix",
+		match:  func(s string) bool { return strings.HasSuffix(s, suffix) },
+	}
+}
+
+// HasSuffixFold is like `HasSuffix` but compares the strings
+// case-insensitively.
+func (c *C) HasSuffixFold(suffix string) *StringTester {
+	return &StringTester{
+		expect: suffix,
+		op:     "has suffix (case-insensitive)",
+		match: func(s string) bool {
+			return strings.HasSuffix(strings.ToLower(s), strings.ToLower(suffix))
+		},
+	}
+}
+
+// ContainsString returns a `*StringTester` which passes if the actual value
+// contains the given substring.
+func (c *C) ContainsString(substr string) *StringTester {
+	return &StringTester{
+		expect: substr,
+		op:     "contains",
+		match:  func(s string) bool { return strings.Contains(s, substr) },
+	}
+}
+
+// ContainsStringFold is like `ContainsString` but compares the strings
+// case-insensitively.
+func (c *C) ContainsStringFold(substr string) *StringTester {
+	return &StringTester{
+		expect: substr,
+		op:     "contains (case-insensitive)",
+		match: func(s string) bool {
+			return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+		},
+	}
+}
+
+func (st *StringTester) Test(c *C, actual any) []*Result {
+	if st.err != nil {
+		return []*Result{
+			c.FailUsage(fmt.Sprintf("Invalid pattern: %s", st.err)),
+		}
+	}
+
+	s, ok := stringOf(actual)
+	if !ok {
+		return []*Result{
+			c.FailType(actual, st.expect, st.op).WithDescription(
+				fmt.Sprintf(
+					"Expected a string, []byte, or fmt.Stringer but got %s",
+					articleize(describeTypeOfActualValue(actual)),
+				),
+			),
+		}
+	}
+
+	if st.match(s) {
+		return []*Result{c.Pass(actual, st.expect, st.op)}
+	}
+	return []*Result{c.FailValue(actual, st.expect, st.op)}
+}
+
+func toRegexp(re any) (*regexp.Regexp, error) {
+	switch r := re.(type) {
+	case *regexp.Regexp:
+		return r, nil
+	case string:
+		return regexp.Compile(r)
+	}
+	return nil, fmt.Errorf(
+		"expected a string or *regexp.Regexp but got %s",
+		articleize(describeTypeOfActualValue(re)),
+	)
+}
+
+// stringOf returns the string for a string-like value. The second return value
+// is false if the value is not string-like.
+func stringOf(actual any) (string, bool) {
+	switch a := actual.(type) {
+	case string:
+		return a, true
+	case []byte:
+		return string(a), true
+	case fmt.Stringer:
+		if v := reflect.ValueOf(a); v.Kind() == reflect.Ptr && v.IsNil() {
+			return "", false
+		}
+		return a.String(), true
+	}
+
+	v := reflect.ValueOf(actual)
+	// nolint: exhaustive
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,134 @@
+package contesta
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stringerID int
+
+func (s stringerID) String() string {
+	return "id-42"
+}
+
+func TestStringTesters(t *testing.T) {
+	tests := map[string]contesterTest{
+		"Like string": {
+			actual: "error at 12:34:56",
+			expect: NewWithOutput(nil, nil).Like(`^error at \d+:\d+:\d+$`),
+			pass:   true,
+		},
+		"Like regexp no match": {
+			actual: "warning",
+			expect: NewWithOutput(nil, nil).Like(regexp.MustCompile(`^error`)),
+			pass:   false,
+		},
+		"Like invalid pattern": {
+			actual: "error",
+			expect: NewWithOutput(nil, nil).Like(`(`),
+			pass:   false,
+		},
+		"Like []byte": {
+			actual: []byte("error"),
+			expect: NewWithOutput(nil, nil).Like(`err`),
+			pass:   true,
+		},
+		"Like fmt.Stringer": {
+			actual: stringerID(42),
+			expect: NewWithOutput(nil, nil).Like(`^id-\d+$`),
+			pass:   true,
+		},
+		"Like int": {
+			actual: 42,
+			expect: NewWithOutput(nil, nil).Like(`42`),
+			pass:   false,
+		},
+		"Unlike": {
+			actual: "ok",
+			expect: NewWithOutput(nil, nil).Unlike(`error`),
+			pass:   true,
+		},
+		"HasPrefix": {
+			actual: "foobar",
+			expect: NewWithOutput(nil, nil).HasPrefix("foo"),
+			pass:   true,
+		},
+		"HasPrefixFold": {
+			actual: "FOObar",
+			expect: NewWithOutput(nil, nil).HasPrefixFold("foo"),
+			pass:   true,
+		},
+		"HasSuffix": {
+			actual: "foobar",
+			expect: NewWithOutput(nil, nil).HasSuffix("foo"),
+			pass:   false,
+		},
+		"HasSuffixFold": {
+			actual: "fooBAR",
+			expect: NewWithOutput(nil, nil).HasSuffixFold("bar"),
+			pass:   true,
+		},
+		"ContainsString": {
+			actual: "foobar",
+			expect: NewWithOutput(nil, nil).ContainsString("oba"),
+			pass:   true,
+		},
+		"ContainsStringFold": {
+			actual: "fooBar",
+			expect: NewWithOutput(nil, nil).ContainsStringFold("OBA"),
+			pass:   true,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("Like result", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is("warning", c.Like(`^error`))
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, "=~", res[0].Op())
+			expect, _ := res[0].Expect()
+			assert.Equal(t, regexp.MustCompile(`^error`), expect)
+			actual, _ := res[0].Actual()
+			assert.Equal(t, "warning", actual)
+		}
+	})
+
+	t.Run("Like output", func(t *testing.T) {
+		lines := plainOutput(t, "warning", func(c *C) Contester { return c.Like(`^error`) })
+		if assert.Len(t, lines, 1) {
+			assert.Contains(t, lines[0], "got string warning expected =~ *Regexp ^error")
+		}
+	})
+
+	t.Run("Unlike output", func(t *testing.T) {
+		lines := plainOutput(t, "an error", func(c *C) Contester { return c.Unlike(`error`) })
+		if assert.Len(t, lines, 1) {
+			assert.Contains(t, lines[0], "got string an error expected !~ *Regexp error")
+		}
+	})
+
+	t.Run("invalid pattern description", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is("error", c.Like(`(`))
+		if assert.Len(t, res, 1) {
+			assert.Equal(t, inUsage, res[0].where)
+			assert.Equal(
+				t,
+				"Invalid pattern: error parsing regexp: missing closing ): `(`",
+				res[0].Description(),
+			)
+		}
+	})
+
+	t.Run("nested in a map", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(map[string]string{"id": "user-123"}, c.Map(c.Key("id").Is(c.Like(`^user-\d+$`))))
+		m.AssertPassed(t)
+	})
+}
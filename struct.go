@@ -0,0 +1,203 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// StructTester tests the fields of a struct or a pointer to a struct.
+type StructTester struct {
+	tests           []StructTest
+	caller          string
+	allowUnexported bool
+}
+
+// StructTest is the interface for anything that can be passed to `c.Struct`.
+type StructTest interface {
+	isStructTest()
+	Test(c *C, value reflect.Value) []*Result
+}
+
+// Struct takes one or more `StructTest` values and returns a
+// `*StructTester`. The tests are usually created by calling
+// `c.Field(name).Is(expected)`. The returned tester works on structs and
+// pointers to structs.
+//
+// As with `c.Map`, `c.End()` is added if neither `c.End()` nor
+// `c.NonExhaustive()` is given. When checking for untested fields, only
+// exported fields are considered unless `AllowUnexported` is called on the
+// tester.
+func (c *C) Struct(test StructTest, tests ...StructTest) *StructTester {
+	tests = append([]StructTest{test}, tests...)
+
+	var ordered, terminators []StructTest
+	for _, t := range tests {
+		if isContainerTerminator(t) {
+			terminators = append(terminators, t)
+		} else {
+			ordered = append(ordered, t)
+		}
+	}
+	if len(terminators) == 0 {
+		terminators = append(terminators, End())
+	}
+
+	return &StructTester{
+		tests:  append(ordered, terminators...),
+		caller: c.Caller(),
+	}
+}
+
+// AllowUnexported allows the tester's field tests to look at unexported
+// fields. It also makes `c.End()` require that unexported fields are tested.
+func (st *StructTester) AllowUnexported() *StructTester {
+	st.allowUnexported = true
+	return st
+}
+
+func (st *StructTester) Test(c *C, actual any) []*Result {
+	c.SetCaller(st.caller)
+	defer c.UnsetCaller()
+
+	va := reflect.ValueOf(actual)
+	for va.Kind() == reflect.Ptr {
+		if va.IsNil() {
+			return []*Result{{
+				pass:        false,
+				actual:      newValue(actual),
+				description: "Expected a pointer to a struct but got a nil pointer",
+				paths:       c.Paths(),
+				where:       inDataStructure,
+			}}
+		}
+		va = va.Elem()
+	}
+
+	if va.Kind() != reflect.Struct {
+		return unexpectedKindResult(c, "a struct", reflect.TypeOf(actual))
+	}
+
+	c.pushContainer()
+	defer c.popContainer()
+	c.container().allowUnexported = st.allowUnexported
+
+	var res []*Result
+	for _, t := range st.tests {
+		res = append(res, t.Test(c, va)...)
+	}
+
+	return res
+}
+
+// IncompleteFieldTest is returned by `c.Field(name)`. Call its `Is` method to
+// turn it into a `FieldTest`.
+type IncompleteFieldTest struct {
+	name string
+	c    *C
+}
+
+// FieldTest tests a single field of a struct.
+type FieldTest struct {
+	name   string
+	test   any
+	caller string
+}
+
+// Field returns an `IncompleteFieldTest` for the named field.
+func (c *C) Field(name string) IncompleteFieldTest {
+	return IncompleteFieldTest{name, c}
+}
+
+func (ift IncompleteFieldTest) Is(expected any) FieldTest {
+	return FieldTest{
+		name:   ift.name,
+		test:   expected,
+		caller: ift.c.Caller(),
+	}
+}
+
+func (FieldTest) isStructTest() {}
+
+func (ft FieldTest) Test(c *C, value reflect.Value) []*Result {
+	c.PushPath(Path{
+		data:   "." + ft.name,
+		callee: "contesta.IncompleteFieldTest.Is",
+		caller: ft.caller,
+	})
+	defer c.PopPath()
+
+	sf, ok := value.Type().FieldByName(ft.name)
+	if !ok {
+		return []*Result{{
+			pass: false,
+			description: fmt.Sprintf(
+				"The %s struct has no field named %s",
+				describeType(value.Type()),
+				ft.name,
+			),
+			paths: c.Paths(),
+			where: inDataStructure,
+		}}
+	}
+
+	if !sf.IsExported() && !c.container().allowUnexported {
+		return []*Result{{
+			pass: false,
+			description: fmt.Sprintf(
+				"The %s field is not exported."+
+					" Call AllowUnexported() on the struct tester to test it.",
+				ft.name,
+			),
+			paths: c.Paths(),
+			where: inUsage,
+		}}
+	}
+
+	field, err := fieldValue(value, sf.Index)
+	if err != nil {
+		return []*Result{{
+			pass:        false,
+			description: fmt.Sprintf("Cannot get the %s field: %s", ft.name, err),
+			paths:       c.Paths(),
+			where:       inDataStructure,
+		}}
+	}
+
+	c.markChecked(ft.name)
+	return c.is(field.Interface(), ft.test)
+}
+
+// fieldValue returns the field at the given index, making it possible to call
+// `Interface()` on the returned value even if the field is not exported.
+func fieldValue(value reflect.Value, index []int) (reflect.Value, error) {
+	if !value.CanAddr() {
+		cp := reflect.New(value.Type()).Elem()
+		cp.Set(value)
+		value = cp
+	}
+
+	field, err := value.FieldByIndexErr(index)
+	if err != nil {
+		return field, err
+	}
+
+	if !field.CanInterface() {
+		// nolint: gosec
+		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+	}
+
+	return field, nil
+}
+
+type StructFieldsNotCheckedFailure struct {
+	fields []string
+}
+
+func (sfnf StructFieldsNotCheckedFailure) Failure() string {
+	return fmt.Sprintf(
+		"%s not checked: %v",
+		countOf(len(sfnf.fields), "field in the struct", "fields in the struct"),
+		sfnf.fields,
+	)
+}
@@ -0,0 +1,141 @@
+package contesta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name  string
+	Email string
+	id    int
+}
+
+type Timestamps struct {
+	Created int
+	Updated int
+}
+
+type record struct {
+	Timestamps
+	ID int
+}
+
+type response struct {
+	User *user
+}
+
+func TestStruct(t *testing.T) {
+	t.Run("all fields match", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(
+			user{Name: "Joe", Email: "joe@example.com"},
+			c.Struct(c.Field("Name").Is("Joe"), c.Field("Email").Is("joe@example.com")),
+		)
+		m.AssertPassed(t)
+	})
+	t.Run("field does not match", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(user{Name: "Joe"}, c.Struct(c.Field("Name").Is("Jane"), NonExhaustive()))
+		m.AssertFailed(t)
+	})
+	t.Run("pointer to struct", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(&user{Name: "Joe"}, c.Struct(c.Field("Name").Is("Joe"), NonExhaustive()))
+		m.AssertPassed(t)
+	})
+	t.Run("nil pointer", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is((*user)(nil), c.Struct(c.Field("Name").Is("Joe")))
+		m.AssertFailed(t)
+	})
+	t.Run("no such field", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(user{}, c.Struct(c.Field("Age").Is(42), NonExhaustive()))
+		m.AssertFailed(t)
+	})
+	t.Run("unexported field", func(t *testing.T) {
+		t.Run("not allowed", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(user{id: 42}, c.Struct(c.Field("id").Is(42), NonExhaustive()))
+			m.AssertFailed(t)
+		})
+		t.Run("allowed", func(t *testing.T) {
+			m := newMockT()
+			c := NewWithOutput(m, m)
+			c.Is(user{id: 42}, c.Struct(c.Field("id").Is(42), NonExhaustive()).AllowUnexported())
+			m.AssertPassed(t)
+		})
+	})
+	t.Run("End", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(user{Name: "Joe"}, c.Struct(c.Field("Name").Is("Joe")))
+		if assert.Len(t, res, 2) {
+			assert.False(t, res[1].pass, "End failed")
+			assert.Equal(t, "1 field in the struct was not checked: [Email]", res[1].description)
+		}
+	})
+	t.Run("End with more than one field", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		res := c.is(user{Name: "Joe"}, c.Struct(c.Field("id").Is(0)).AllowUnexported())
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, "2 fields in the struct were not checked: [Name Email]", res[1].description)
+		}
+	})
+	t.Run("End with promoted fields", func(t *testing.T) {
+		m := newMockT()
+		c := NewWithOutput(m, m)
+		c.Is(
+			record{Timestamps: Timestamps{Created: 1}, ID: 2},
+			c.Struct(c.Field("Created").Is(1), c.Field("Updated").Is(0), c.Field("ID").Is(2)),
+		)
+		m.AssertPassed(t)
+
+		m = newMockT()
+		c = NewWithOutput(m, m)
+		c.Is(record{ID: 2}, c.Struct(c.Field("Timestamps").Is(Timestamps{}), c.Field("ID").Is(2)))
+		m.AssertPassed(t)
+
+		c.ResetState()
+		res := c.is(record{ID: 2}, c.Struct(c.Field("Created").Is(0), c.Field("ID").Is(2)))
+		if assert.Len(t, res, 3) {
+			assert.Equal(t, "1 field in the struct was not checked: [Updated]", res[2].description)
+		}
+
+		c.ResetState()
+		res = c.is(record{ID: 2}, c.Struct(c.Field("ID").Is(2)))
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, "2 fields in the struct were not checked: [Created Updated]", res[1].description)
+		}
+	})
+	t.Run("nested path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "response"})
+		res := c.is(
+			response{User: &user{Email: "joe@example.com"}},
+			c.Struct(
+				c.Field("User").Is(c.Struct(c.Field("Email").Is("jane@example.com"), NonExhaustive())),
+			),
+		)
+		AssertResultsAre(
+			t,
+			outputItems(res),
+			[]resultExpect{
+				{pass: false, dataPath: []string{"response", ".User", ".Email"}},
+				{pass: true, dataPath: []string{"response", ".User"}},
+				{pass: true, dataPath: []string{"response"}},
+			},
+			"nested structs",
+		)
+	})
+}
@@ -0,0 +1,117 @@
+package contesta
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ViaTester applies a function to the actual value and then tests the
+// function's return value.
+type ViaTester struct {
+	name   string
+	fn     any
+	expect any
+	caller string
+}
+
+// Via returns a `*ViaTester` which calls `fn` with the actual value and then
+// tests the value it returns against `expected`, which can be either a
+// literal value or a Contester. The `name` is pushed as a path element, so it
+// should describe the transformation, like "len()" or ".String()".
+//
+// The function must take exactly one argument, which the actual value must be
+// assignable to. It must return either a single value or a value and an
+// error. If it returns a non-nil error the test fails.
+//
+//	c.Is(users, c.Via("len()", func(u []User) int { return len(u) }, 3))
+func (c *C) Via(name string, fn, expected any) *ViaTester {
+	return &ViaTester{
+		name:   name,
+		fn:     fn,
+		expect: expected,
+		caller: c.Caller(),
+	}
+}
+
+func (vt *ViaTester) Test(c *C, actual any) []*Result {
+	fv := reflect.ValueOf(vt.fn)
+	if desc := checkViaFunc(fv); desc != "" {
+		return []*Result{c.FailUsage(desc)}
+	}
+
+	inType := fv.Type().In(0)
+	arg, ok := argumentValue(actual, inType)
+	if !ok {
+		return []*Result{
+			c.FailType(actual, vt.name, "via").WithDescription(fmt.Sprintf(
+				"Cannot pass %s to a function which takes %s",
+				articleize(describeTypeOfActualValue(actual)),
+				articleize(describeType(inType)),
+			)),
+		}
+	}
+
+	c.PushPath(Path{data: vt.name, callee: "contesta.(*C).Via", caller: vt.caller})
+	defer c.PopPath()
+
+	var out []reflect.Value
+	p := callAndRecover(func() { out = fv.Call([]reflect.Value{arg}) })
+	if p.panicked {
+		return []*Result{{
+			pass:        false,
+			actual:      newValue(p.value),
+			paths:       c.Paths(),
+			where:       inValue,
+			description: fmt.Sprintf("Calling %s panicked:\n%s", vt.name, p.stack),
+		}}
+	}
+
+	if len(out) == 2 && !out[1].IsNil() {
+		err, _ := out[1].Interface().(error)
+		return []*Result{
+			c.failError(err, nil, "").WithDescription(fmt.Sprintf("%s returned an error", vt.name)),
+		}
+	}
+
+	return c.is(out[0].Interface(), vt.expect)
+}
+
+// checkViaFunc returns a description of the problem if the value is not a
+// function that can be used with `c.Via`.
+func checkViaFunc(fv reflect.Value) string {
+	if !fv.IsValid() || fv.Kind() != reflect.Func || fv.IsNil() {
+		return fmt.Sprintf(
+			"Via requires a function but was given %s",
+			articleize(describeTypeOfReflectValue(fv)),
+		)
+	}
+
+	ty := fv.Type()
+	if ty.NumIn() != 1 || ty.IsVariadic() {
+		return "The function passed to Via must take exactly one argument"
+	}
+	if ty.NumOut() == 1 || (ty.NumOut() == 2 && ty.Out(1) == errorType) {
+		return ""
+	}
+
+	return "The function passed to Via must return a single value or a value and an error"
+}
+
+// argumentValue returns a `reflect.Value` for the actual value that can be
+// passed as an argument of the given type. An untyped nil can be passed as any
+// nilable type.
+func argumentValue(actual any, ty reflect.Type) (reflect.Value, bool) {
+	if actual == nil {
+		if isNilable(ty.Kind()) {
+			return reflect.Zero(ty), true
+		}
+		return reflect.Value{}, false
+	}
+
+	av := reflect.ValueOf(actual)
+	if !av.Type().AssignableTo(ty) {
+		return reflect.Value{}, false
+	}
+
+	return av, true
+}
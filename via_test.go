@@ -0,0 +1,68 @@
+package contesta
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestVia(t *testing.T) {
+	c := NewWithOutput(nil, nil)
+	length := func(s []string) int { return len(s) }
+	tests := map[string]contesterTest{
+		"literal": {
+			actual: []string{"a", "b"},
+			expect: c.Via("len()", length, 2),
+			pass:   true,
+		},
+		"literal does not match": {
+			actual: []string{"a", "b"},
+			expect: c.Via("len()", length, 3),
+			pass:   false,
+		},
+		"Contester": {
+			actual: []string{"a", "b"},
+			expect: c.Via("len()", length, c.GreaterThan(1)),
+			pass:   true,
+		},
+		"value and error": {
+			actual: "42",
+			expect: c.Via("strconv.Atoi()", strconv.Atoi, 42),
+			pass:   true,
+		},
+		"returns an error": {
+			actual: "x",
+			expect: c.Via("strconv.Atoi()", strconv.Atoi, 42),
+			pass:   false,
+		},
+		"wrong argument type": {
+			actual: 42,
+			expect: c.Via("len()", length, 2),
+			pass:   false,
+		},
+		"not a function": {
+			actual: 42,
+			expect: c.Via("len()", 42, 2),
+			pass:   false,
+		},
+		"function panics": {
+			actual: 42,
+			expect: c.Via("boom()", func(int) int { panic(errors.New("boom")) }, 2),
+			pass:   false,
+		},
+	}
+
+	runContesterTests(t, tests)
+
+	t.Run("path", func(t *testing.T) {
+		c := NewWithOutput(newMockT(), newMockT())
+		c.ResetState()
+		c.PushPath(Path{data: "[]string"})
+		AssertResultsAre(
+			t,
+			outputItems(c.is([]string{"a"}, c.Via("len()", length, 2))),
+			[]resultExpect{{pass: false, dataPath: []string{"[]string", "len()"}}},
+			"Via",
+		)
+	})
+}